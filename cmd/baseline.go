@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/allowlist"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var baselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Manage the allowlist baseline of known-safe findings",
+	Long: "Manage the allowlist baseline, a file of known-safe finding fingerprints that never block.\n\n" +
+		"The baseline is stored next to config.yaml (baseline.yaml) unless allowlist.baseline_path is set, " +
+		"and only holds fingerprints, expiry dates and justifications, so it is safe to commit.",
+}
+
+// defaultLogExpiry is how long entries added with --from-log apply unless --expires is given
+const defaultLogExpiry = 90 * 24 * time.Hour
+
+var baselineAddCmd = &cobra.Command{
+	Use:   "add [fingerprint...]",
+	Short: "Add finding fingerprints to the baseline",
+	Long: "Add finding fingerprints to the baseline, given as arguments or read with --from-log " +
+		"from the JSON findings file written by the log remediation strategy.\n\n" +
+		"A findings log also records real leaked credentials, so review the entries printed by --dry-run " +
+		"and narrow them with --type and --since. Entries from a log expire after 90 days unless --expires is given.",
+	RunE: runBaselineAdd,
+}
+
+var baselineListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List baseline entries with their expiry and justification",
+	Args:  cobra.NoArgs,
+	RunE:  runBaselineList,
+}
+
+var baselinePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove expired entries from the baseline",
+	Args:  cobra.NoArgs,
+	RunE:  runBaselinePrune,
+}
+
+func init() {
+	baselineCmd.PersistentFlags().String("file", "", "Path to the baseline file (default: baseline.yaml next to the config file)")
+
+	baselineAddCmd.Flags().String("from-log", "", "JSON findings log of the log remediation strategy to read fingerprints from")
+	baselineAddCmd.Flags().String("justification", "", "Why the findings are safe, recorded with each entry")
+	baselineAddCmd.Flags().String("expires", "", "Last day (YYYY-MM-DD) or RFC 3339 time the entries apply, or 'never' (default: never, or 90 days with --from-log)")
+	baselineAddCmd.Flags().StringSlice("type", nil, "With --from-log, only add findings whose type matches one of these globs (e.g. 'aws_*')")
+	baselineAddCmd.Flags().String("since", "", "With --from-log, only add findings logged since a day (YYYY-MM-DD), RFC 3339 time or duration ago (e.g. 72h)")
+	baselineAddCmd.Flags().Bool("dry-run", false, "Print the entries that would be added without changing the baseline")
+
+	baselineCmd.AddCommand(baselineAddCmd)
+	baselineCmd.AddCommand(baselineListCmd)
+	baselineCmd.AddCommand(baselinePruneCmd)
+}
+
+func runBaselineAdd(cmd *cobra.Command, args []string) error {
+	fromLog, _ := cmd.Flags().GetString("from-log")
+	justification, _ := cmd.Flags().GetString("justification")
+	expires, _ := cmd.Flags().GetString("expires")
+	typeGlobs, _ := cmd.Flags().GetStringSlice("type")
+	since, _ := cmd.Flags().GetString("since")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	if fromLog == "" && len(args) == 0 {
+		return fmt.Errorf("no fingerprints given; pass them as arguments or use --from-log")
+	}
+	if fromLog == "" && (len(typeGlobs) > 0 || since != "") {
+		return fmt.Errorf("--type and --since filter --from-log and need it")
+	}
+
+	switch {
+	case expires == "never":
+		expires = ""
+	case expires == "" && fromLog != "":
+		expires = time.Now().Add(defaultLogExpiry).Format(time.DateOnly)
+	case expires != "":
+		if _, err := allowlist.ParseExpiry(expires); err != nil {
+			return err
+		}
+	}
+
+	filter := allowlist.LogFilter{Types: typeGlobs}
+	for _, glob := range typeGlobs {
+		if _, err := path.Match(glob, ""); err != nil {
+			return fmt.Errorf("invalid --type glob %q", glob)
+		}
+	}
+	if since != "" {
+		t, err := parseSince(since, time.Now())
+		if err != nil {
+			return err
+		}
+		filter.Since = t
+	}
+
+	for _, fingerprint := range args {
+		if !allowlist.ValidFingerprint(fingerprint) {
+			return fmt.Errorf("invalid fingerprint %q (expected sha256:<64 hex digits>)", fingerprint)
+		}
+	}
+
+	// Logged findings describe the entries added for them
+	fingerprints := args
+	described := make(map[string]allowlist.LoggedFinding)
+	if fromLog != "" {
+		logged, err := readLoggedFindings(cmd, fromLog, filter)
+		if err != nil {
+			return err
+		}
+		for _, finding := range logged {
+			fingerprint := allowlist.NormalizeFingerprint(finding.Fingerprint)
+			if _, ok := described[fingerprint]; !ok {
+				described[fingerprint] = finding
+			}
+			fingerprints = append(fingerprints, fingerprint)
+		}
+
+		if justification == "" {
+			justification = "Baselined from " + filepath.Base(fromLog)
+		}
+	}
+
+	baseline := baselinePath(cmd)
+	entries, err := loadBaselineFile(baseline)
+	if err != nil {
+		return err
+	}
+
+	entries, added := allowlist.AddFingerprints(entries, fingerprints, justification, expires)
+	if len(added) > 0 && !dryRun {
+		if err := config.SaveBaseline(baseline, entries); err != nil {
+			return err
+		}
+	}
+
+	verb := "Added"
+	if dryRun {
+		verb = "Would add"
+	}
+	for _, fingerprint := range added {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %s%s\n", verb, fingerprint, describeFinding(described[fingerprint]))
+	}
+
+	expiry := "never expire"
+	if expires != "" {
+		expiry = "expire " + expires
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s %d entries to %s, which %s (%d duplicates skipped)\n", verb, len(added), baseline, expiry, len(fingerprints)-len(added))
+	return nil
+}
+
+// parseSince parses --since as a day, an RFC 3339 time or a duration before now
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if day, err := time.Parse(time.DateOnly, value); err == nil {
+		return day, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q (expected YYYY-MM-DD, RFC 3339 or a duration such as 72h)", value)
+}
+
+// describeFinding returns the type and location of a logged finding, or nothing for a fingerprint given by hand
+func describeFinding(finding allowlist.LoggedFinding) string {
+	if finding.Type == "" {
+		return ""
+	}
+	location := finding.Location
+	if location == "" {
+		location = "inline content"
+	}
+	return fmt.Sprintf(" (%s in %s)", finding.Type, location)
+}
+
+func runBaselineList(cmd *cobra.Command, args []string) error {
+	path := baselinePath(cmd)
+	entries, err := loadBaselineFile(path)
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No entries in %s\n", path)
+		return nil
+	}
+
+	now := time.Now()
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MATCHER\tEXPIRES\tJUSTIFICATION")
+	for _, entry := range entries {
+		expires := entry.Expires
+		switch {
+		case expires == "":
+			expires = "never"
+		case allowlist.Expired(entry, now):
+			expires += " (expired)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", entryMatcher(entry), expires, entry.Justification)
+	}
+
+	return w.Flush()
+}
+
+func runBaselinePrune(cmd *cobra.Command, args []string) error {
+	path := baselinePath(cmd)
+	entries, err := loadBaselineFile(path)
+	if err != nil {
+		return err
+	}
+
+	kept, expired := allowlist.Prune(entries, time.Now())
+	if len(expired) > 0 {
+		if err := config.SaveBaseline(path, kept); err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range expired {
+		fmt.Fprintf(cmd.OutOrStdout(), "Removed %s (expired %s)\n", entryMatcher(entry), entry.Expires)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Pruned %d expired entries from %s (%d remaining)\n", len(expired), path, len(kept))
+	return nil
+}
+
+// baselinePath returns the baseline file selected by --file or the configuration
+func baselinePath(cmd *cobra.Command) string {
+	if path, _ := cmd.Flags().GetString("file"); path != "" {
+		return path
+	}
+	return config.GetBaselinePath()
+}
+
+// loadBaselineFile reads the baseline entries, treating a missing file as an empty baseline
+func loadBaselineFile(path string) ([]config.AllowlistEntry, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	return config.LoadBaseline(path)
+}
+
+// readLoggedFindings returns the findings of a JSON findings log that the filter selects
+// Findings that are already suppressed, carry no fingerprint or a malformed one are skipped
+func readLoggedFindings(cmd *cobra.Command, path string, filter allowlist.LogFilter) ([]allowlist.LoggedFinding, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open findings log; %w", err)
+	}
+	defer file.Close()
+
+	findings, skipped, err := allowlist.ReadLoggedFindings(file)
+	if err != nil {
+		return nil, err
+	}
+	if skipped > 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Skipped %d lines of %s that are not JSON log entries (the log strategy must use format: json)\n", skipped, path)
+	}
+
+	selected, invalid := allowlist.SelectLogged(findings, filter)
+	for _, finding := range invalid {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Skipped malformed fingerprint %q%s\n", finding.Fingerprint, describeFinding(finding))
+	}
+
+	return selected, nil
+}
+
+// entryMatcher describes what an entry matches
+func entryMatcher(entry config.AllowlistEntry) string {
+	var matchers []string
+	if entry.Fingerprint != "" {
+		matchers = append(matchers, entry.Fingerprint)
+	}
+	if entry.Pattern != "" {
+		matchers = append(matchers, "pattern "+entry.Pattern)
+	}
+	if entry.Path != "" {
+		matchers = append(matchers, "path "+entry.Path)
+	}
+	return strings.Join(matchers, ", ")
+}
@@ -1,41 +1,46 @@
 package cmd
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/daemon"
 	"github.com/leefowlercu/agent-hook-vault-radar/internal/processor"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var rootCmd = &cobra.Command{
-	Use:   "hook-vault-radar",
+	Use:   "hook-vault-radar [payload]",
 	Short: "Hook framework integration for Vault Radar scanning",
 	Long: "\nhook-vault-radar is a CLI tool that integrates with AI agent hook frameworks " +
 		"to scan content for secrets and sensitive data using HashiCorp Vault Radar.\n\n" +
-		"It reads hook data from stdin as JSON and outputs decisions to stdout as JSON.",
+		"It reads hook data from stdin as JSON and outputs decisions to stdout as JSON. " +
+		"Frameworks that pass hook data as an argument (e.g., Codex notify) can supply it as [payload] instead.",
+	Args:              cobra.MaximumNArgs(1),
 	PersistentPreRunE: runInit,
 	RunE:              runHook,
 }
 
 func init() {
 	rootCmd.PersistentFlags().String("config", "", "Path to configuration file (default: ~/.agent-hooks/vault-radar/config.yaml)")
-	rootCmd.Flags().String("framework", "", "Hook framework to use (e.g., 'claude')")
+	rootCmd.Flags().String("framework", "", "Hook framework to use (e.g., 'claude', 'cursor', 'gemini', 'codex', 'copilot', 'generic', 'generic:<name>'); detected from the input when omitted")
 	rootCmd.Flags().String("log-level", config.DefaultConfig.Logging.Level, "Logging level (debug, info, warn, error)")
 	rootCmd.Flags().String("log-format", config.DefaultConfig.Logging.Format, "Logging format (json, text)")
 
-	// Mark framework flag as required
-	rootCmd.MarkFlagRequired("framework")
-
 	// Bind flags to viper
 	viper.BindPFlag("framework", rootCmd.Flags().Lookup("framework"))
 	viper.BindPFlag("logging.level", rootCmd.Flags().Lookup("log-level"))
 	viper.BindPFlag("logging.format", rootCmd.Flags().Lookup("log-format"))
 
-	// Add version command
+	// Add subcommands
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(baselineCmd)
 
 	// Enable --version flag on root command
 	rootCmd.Version = version
@@ -57,7 +62,35 @@ func runInit(cmd *cobra.Command, args []string) error {
 func runHook(cmd *cobra.Command, args []string) error {
 	framework := viper.GetString("framework")
 
-	return processor.Process(os.Stdin, os.Stdout, framework)
+	// Codex notify passes the JSON payload as the last argument rather than on stdin
+	var input []byte
+	if len(args) == 1 {
+		input = []byte(args[0])
+	} else {
+		raw, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read stdin; %w", err)
+		}
+		input = raw
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration; %w", err)
+	}
+
+	// Forward to the daemon when daemon.socket_path is set, processing in-process when none answers
+	err = daemon.Forward(cfg, framework, input, os.Stdout, os.Stderr)
+	if errors.Is(err, daemon.ErrUnavailable) {
+		err = processor.Process(bytes.NewReader(input), os.Stdout, os.Stderr, framework)
+	}
+
+	var exitErr *processor.ExitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.Code)
+	}
+
+	return err
 }
 
 func Execute() error {
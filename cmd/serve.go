@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/daemon"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/processor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run a hook daemon on a Unix socket",
+	Long: "Run a persistent daemon that keeps a warm processor listening on a Unix domain socket.\n\n" +
+		"Hook invocations with daemon.socket_path set forward their input to the daemon and relay its output, " +
+		"falling back to in-process processing when the daemon is not running or uses a different configuration. " +
+		"Send SIGHUP to reload the configuration; SIGINT or SIGTERM shuts the daemon down, " +
+		"cancelling the scans of in-flight hooks, which then process their input in-process.",
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	serveCmd.Flags().String("socket", "", "Path of the Unix socket to listen on (default: daemon.socket_path, or ~/.agent-hooks/vault-radar/daemon.sock)")
+
+	// Bind flags to viper
+	viper.BindPFlag("daemon.socket_path", serveCmd.Flags().Lookup("socket"))
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+
+	// Reloads re-read the configuration file before building a new processor
+	load := func() (*processor.Processor, error) {
+		if err := config.InitConfig(configPath); err != nil {
+			return nil, fmt.Errorf("failed to initialize configuration; %w", err)
+		}
+		return processor.Load()
+	}
+
+	socketPath := viper.GetString("daemon.socket_path")
+	if socketPath == "" {
+		socketPath = config.DefaultSocketPath
+	}
+
+	server, err := daemon.NewServer(socketPath, load)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	go func() {
+		for {
+			select {
+			case <-hangup:
+				server.Reload()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	fmt.Fprintf(os.Stderr, "hook-vault-radar daemon listening on %s\n", server.SocketPath())
+
+	return server.Serve(ctx)
+}
@@ -0,0 +1,203 @@
+package allowlist
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+// fingerprintPrefix identifies the hash algorithm of a fingerprint
+const fingerprintPrefix = "sha256:"
+
+// fingerprintPattern matches a normalized fingerprint
+var fingerprintPattern = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+// Entry is a compiled allowlist entry
+type Entry struct {
+	Fingerprint   string
+	Pattern       *regexp.Regexp
+	Path          *regexp.Regexp
+	Expires       time.Time // Zero when the entry never expires
+	Justification string
+}
+
+// List holds the allowlist entries from the configuration and the baseline file
+type List struct {
+	entries []Entry
+}
+
+// New compiles the configured entries and the baseline file
+// Invalid entries are skipped and reported in the returned error; the valid ones are still used
+func New(cfg config.AllowlistConfig) (*List, error) {
+	raw := cfg.Entries
+
+	var errs []error
+	if cfg.BaselinePath != "" {
+		baseline, err := config.LoadBaseline(cfg.BaselinePath)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		raw = append(raw[:len(raw):len(raw)], baseline...)
+	}
+
+	list := &List{}
+	for i, entry := range raw {
+		compiled, err := compile(entry)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("allowlist entry %d: %w", i+1, err))
+			continue
+		}
+		list.entries = append(list.entries, compiled)
+	}
+
+	return list, errors.Join(errs...)
+}
+
+// Match returns the first unexpired entry matching the finding
+// value is the secret the finding points at, or empty when the finding carries no span
+func (l *List) Match(finding types.Finding, value string, now time.Time) (Entry, bool) {
+	if l == nil {
+		return Entry{}, false
+	}
+
+	for _, entry := range l.entries {
+		if !entry.Expires.IsZero() && now.After(entry.Expires) {
+			continue
+		}
+		if entry.matches(finding, value) {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// matches reports whether every matcher set on the entry matches the finding
+func (e Entry) matches(finding types.Finding, value string) bool {
+	if e.Fingerprint != "" && (finding.Fingerprint == "" || e.Fingerprint != finding.Fingerprint) {
+		return false
+	}
+	if e.Pattern != nil && (value == "" || !e.Pattern.MatchString(value)) {
+		return false
+	}
+	if e.Path != nil && !e.Path.MatchString(filepath.ToSlash(finding.Location)) {
+		return false
+	}
+	return true
+}
+
+// Fingerprint identifies a secret of a finding type without revealing it
+func Fingerprint(findingType, value string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(findingType) + "\x00" + value))
+	return fingerprintPrefix + hex.EncodeToString(sum[:])
+}
+
+// ValidFingerprint reports whether a fingerprint is a SHA-256 hex digest, with or without the "sha256:" prefix
+func ValidFingerprint(fingerprint string) bool {
+	return fingerprintPattern.MatchString(NormalizeFingerprint(fingerprint))
+}
+
+// NormalizeFingerprint lowercases a fingerprint and adds the "sha256:" prefix when it is missing
+func NormalizeFingerprint(fingerprint string) string {
+	fingerprint = strings.ToLower(strings.TrimSpace(fingerprint))
+	if !strings.HasPrefix(fingerprint, fingerprintPrefix) {
+		fingerprint = fingerprintPrefix + fingerprint
+	}
+	return fingerprint
+}
+
+// compile validates an entry and compiles its matchers
+func compile(entry config.AllowlistEntry) (Entry, error) {
+	compiled := Entry{Justification: entry.Justification}
+
+	if entry.Fingerprint == "" && entry.Pattern == "" && entry.Path == "" {
+		return compiled, fmt.Errorf("no fingerprint, pattern or path set")
+	}
+
+	if entry.Fingerprint != "" {
+		compiled.Fingerprint = NormalizeFingerprint(entry.Fingerprint)
+	}
+
+	if entry.Pattern != "" {
+		pattern, err := regexp.Compile(entry.Pattern)
+		if err != nil {
+			return compiled, fmt.Errorf("invalid pattern; %w", err)
+		}
+		compiled.Pattern = pattern
+	}
+
+	if entry.Path != "" {
+		path, err := globToRegexp(filepath.ToSlash(entry.Path))
+		if err != nil {
+			return compiled, fmt.Errorf("invalid path; %w", err)
+		}
+		compiled.Path = path
+	}
+
+	if entry.Expires != "" {
+		expires, err := ParseExpiry(entry.Expires)
+		if err != nil {
+			return compiled, err
+		}
+		compiled.Expires = expires
+	}
+
+	return compiled, nil
+}
+
+// ParseExpiry parses an RFC 3339 time, or a date that stays valid until the end of that day (UTC)
+func ParseExpiry(value string) (time.Time, error) {
+	if expires, err := time.Parse(time.RFC3339, value); err == nil {
+		return expires, nil
+	}
+
+	day, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expires %q (expected YYYY-MM-DD or RFC 3339)", value)
+	}
+
+	return day.Add(24*time.Hour - time.Nanosecond), nil
+}
+
+// globToRegexp converts a path glob to a regular expression matching the whole location
+// "*" and "?" stay within a path segment, "**" spans segments, and a glob without "/" matches the base name
+func globToRegexp(glob string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	if !strings.Contains(glob, "/") {
+		sb.WriteString("(?:.*/)?")
+	}
+
+	runes := []rune(glob)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				i++
+				// "**/" also matches no directory at all
+				if i+1 < len(runes) && runes[i+1] == '/' {
+					i++
+					sb.WriteString("(?:.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
@@ -0,0 +1,156 @@
+package allowlist
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+// maxLogLineSize bounds a single JSON log entry read by ReadLoggedFindings
+const maxLogLineSize = 10 * 1024 * 1024
+
+// LoggedFinding is a finding read from a findings log
+type LoggedFinding struct {
+	types.Finding
+	Logged time.Time // Timestamp of the log entry; zero when it has none
+}
+
+// LogFilter selects the logged findings to add to a baseline
+type LogFilter struct {
+	Types []string  // Finding type globs, ignoring case; empty selects every type
+	Since time.Time // Earliest log entry; zero selects every entry
+}
+
+// ReadLoggedFindings returns the findings recorded by the log remediation strategy in JSON format
+// Lines that are not JSON log entries (e.g. text format) are skipped and counted
+func ReadLoggedFindings(r io.Reader) ([]LoggedFinding, int, error) {
+	var findings []LoggedFinding
+	skipped := 0
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry struct {
+			Timestamp string          `json:"timestamp"`
+			Findings  []types.Finding `json:"findings"`
+		}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			skipped++
+			continue
+		}
+
+		logged, _ := time.Parse(time.RFC3339, entry.Timestamp)
+		for _, finding := range entry.Findings {
+			findings = append(findings, LoggedFinding{Finding: finding, Logged: logged})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, skipped, fmt.Errorf("failed to read findings log; %w", err)
+	}
+
+	return findings, skipped, nil
+}
+
+// SelectLogged returns the logged findings the filter selects that are not suppressed and have a fingerprint
+// Findings with a malformed fingerprint are left out and returned separately
+func SelectLogged(findings []LoggedFinding, filter LogFilter) (selected, invalid []LoggedFinding) {
+	for _, finding := range findings {
+		if finding.Fingerprint == "" || finding.Suppressed || !filter.matches(finding) {
+			continue
+		}
+		if !ValidFingerprint(finding.Fingerprint) {
+			invalid = append(invalid, finding)
+			continue
+		}
+		selected = append(selected, finding)
+	}
+	return selected, invalid
+}
+
+// matches reports whether the filter selects a logged finding
+// A finding without a timestamp is not selected once Since is set
+func (f LogFilter) matches(finding LoggedFinding) bool {
+	if !f.Since.IsZero() && finding.Logged.Before(f.Since) {
+		return false
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, glob := range f.Types {
+		if ok, _ := path.Match(strings.ToLower(glob), strings.ToLower(finding.Type)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// AddFingerprints appends an entry for each fingerprint that the baseline does not contain yet
+// It returns the updated entries and the normalized fingerprints that were added
+func AddFingerprints(entries []config.AllowlistEntry, fingerprints []string, justification, expires string) ([]config.AllowlistEntry, []string) {
+	known := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.Fingerprint != "" {
+			known[NormalizeFingerprint(entry.Fingerprint)] = true
+		}
+	}
+
+	var added []string
+	for _, fingerprint := range fingerprints {
+		if fingerprint == "" {
+			continue
+		}
+
+		fingerprint = NormalizeFingerprint(fingerprint)
+		if known[fingerprint] {
+			continue
+		}
+		known[fingerprint] = true
+
+		entries = append(entries, config.AllowlistEntry{
+			Fingerprint:   fingerprint,
+			Expires:       expires,
+			Justification: justification,
+		})
+		added = append(added, fingerprint)
+	}
+
+	return entries, added
+}
+
+// Prune separates entries that have expired from those that still apply
+// Entries with an unparsable expiry are kept so they can be fixed by hand
+func Prune(entries []config.AllowlistEntry, now time.Time) (kept, expired []config.AllowlistEntry) {
+	kept = []config.AllowlistEntry{}
+	for _, entry := range entries {
+		if Expired(entry, now) {
+			expired = append(expired, entry)
+		} else {
+			kept = append(kept, entry)
+		}
+	}
+	return kept, expired
+}
+
+// Expired reports whether an entry's expiry has passed
+func Expired(entry config.AllowlistEntry, now time.Time) bool {
+	if entry.Expires == "" {
+		return false
+	}
+
+	expires, err := ParseExpiry(entry.Expires)
+	return err == nil && now.After(expires)
+}
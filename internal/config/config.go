@@ -1,6 +1,8 @@
 package config
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -8,6 +10,7 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
 )
 
 // InitConfig initializes the configuration using Viper
@@ -29,15 +32,46 @@ func InitConfig(configPath string) error {
 	}
 
 	// Set defaults
+	viper.SetDefault("scanner.type", DefaultConfig.Scanner.Type)
+	viper.SetDefault("scanner.engines", DefaultConfig.Scanner.Engines)
+	viper.SetDefault("cache.enabled", DefaultConfig.Cache.Enabled)
+	viper.SetDefault("cache.dir", DefaultConfig.Cache.Dir)
+	viper.SetDefault("cache.ttl_seconds", DefaultConfig.Cache.TTLSeconds)
 	viper.SetDefault("vault_radar.command", DefaultConfig.VaultRadar.Command)
 	viper.SetDefault("vault_radar.scan_command", DefaultConfig.VaultRadar.ScanCommand)
 	viper.SetDefault("vault_radar.timeout_seconds", DefaultConfig.VaultRadar.TimeoutSeconds)
 	viper.SetDefault("vault_radar.extra_args", DefaultConfig.VaultRadar.ExtraArgs)
+	viper.SetDefault("vault_radar.content_transfer", DefaultConfig.VaultRadar.ContentTransfer)
+	viper.SetDefault("vault_radar.scan_dir", DefaultConfig.VaultRadar.ScanDir)
+	viper.SetDefault("gitleaks.command", DefaultConfig.Gitleaks.Command)
+	viper.SetDefault("gitleaks.config_path", DefaultConfig.Gitleaks.ConfigPath)
+	viper.SetDefault("gitleaks.timeout_seconds", DefaultConfig.Gitleaks.TimeoutSeconds)
+	viper.SetDefault("gitleaks.extra_args", DefaultConfig.Gitleaks.ExtraArgs)
+	viper.SetDefault("trufflehog.command", DefaultConfig.Trufflehog.Command)
+	viper.SetDefault("trufflehog.timeout_seconds", DefaultConfig.Trufflehog.TimeoutSeconds)
+	viper.SetDefault("trufflehog.extra_args", DefaultConfig.Trufflehog.ExtraArgs)
 	viper.SetDefault("logging.level", DefaultConfig.Logging.Level)
 	viper.SetDefault("logging.format", DefaultConfig.Logging.Format)
 	viper.SetDefault("logging.log_file", DefaultConfig.Logging.LogFile)
 	viper.SetDefault("decision.block_on_findings", DefaultConfig.Decision.BlockOnFindings)
 	viper.SetDefault("decision.severity_threshold", DefaultConfig.Decision.SeverityThreshold)
+	viper.SetDefault("decision.mode", DefaultConfig.Decision.Mode)
+	viper.SetDefault("decision.severity_actions", DefaultConfig.Decision.SeverityActions)
+	viper.SetDefault("decision.on_scan_error", DefaultConfig.Decision.OnScanError)
+	viper.SetDefault("decision.rules", DefaultConfig.Decision.Rules)
+	viper.SetDefault("decision.default_action", DefaultConfig.Decision.DefaultAction)
+	viper.SetDefault("decision.overrides", DefaultConfig.Decision.Overrides)
+	viper.SetDefault("allowlist.baseline_path", DefaultConfig.Allowlist.BaselinePath)
+	viper.SetDefault("allowlist.entries", DefaultConfig.Allowlist.Entries)
+	viper.SetDefault("generic.event_field", DefaultConfig.Generic.EventField)
+	viper.SetDefault("generic.output.allow", DefaultConfig.Generic.Output.Allow)
+	viper.SetDefault("generic.output.block", DefaultConfig.Generic.Output.Block)
+	viper.SetDefault("generic.exit_codes.allow", DefaultConfig.Generic.ExitCodes.Allow)
+	viper.SetDefault("generic.exit_codes.block", DefaultConfig.Generic.ExitCodes.Block)
+	viper.SetDefault("daemon.socket_path", DefaultConfig.Daemon.SocketPath)
+	viper.SetDefault("daemon.timeout_seconds", DefaultConfig.Daemon.TimeoutSeconds)
+	viper.SetDefault("project.enabled", DefaultConfig.Project.Enabled)
+	viper.SetDefault("project.allow_loosening", DefaultConfig.Project.AllowLoosening)
 
 	// Enable environment variable overrides
 	viper.SetEnvPrefix("HOOK_VAULT_RADAR")
@@ -68,9 +102,116 @@ func GetConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config; %w", err)
 	}
 
+	// A baseline next to the config file is used when none is configured
+	if cfg.Allowlist.BaselinePath == "" {
+		if _, err := os.Stat(GetBaselinePath()); err == nil {
+			cfg.Allowlist.BaselinePath = GetBaselinePath()
+		}
+	}
+
 	return &cfg, nil
 }
 
+// GetBaselinePath returns the allowlist baseline file
+// It is allowlist.baseline_path when set, otherwise baseline.yaml next to the config file in use
+func GetBaselinePath() string {
+	if path := viper.GetString("allowlist.baseline_path"); path != "" {
+		return path
+	}
+
+	dir := GetDefaultConfigDir()
+	if used := viper.ConfigFileUsed(); used != "" {
+		dir = filepath.Dir(used)
+	}
+
+	return filepath.Join(dir, DefaultBaselineName)
+}
+
+// LoadBaseline reads the allowlist entries stored under "entries" in a YAML or JSON baseline file
+func LoadBaseline(path string) ([]AllowlistEntry, error) {
+	path, err := ExpandHome(path)
+	if err != nil {
+		return nil, err
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read baseline; %w", err)
+	}
+
+	var entries []AllowlistEntry
+	if err := v.UnmarshalKey("entries", &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline; %w", err)
+	}
+
+	return entries, nil
+}
+
+// SaveBaseline writes allowlist entries to a YAML baseline file, replacing its contents
+// The file only holds fingerprints, expiry dates and justifications, so it is safe to commit
+func SaveBaseline(path string, entries []AllowlistEntry) error {
+	path, err := ExpandHome(path)
+	if err != nil {
+		return err
+	}
+
+	if entries == nil {
+		entries = []AllowlistEntry{}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(baselineHeader)
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(struct {
+		Entries []AllowlistEntry `yaml:"entries"`
+	}{Entries: entries}); err != nil {
+		return fmt.Errorf("failed to encode baseline; %w", err)
+	}
+	data := buf.Bytes()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create baseline directory; %w", err)
+	}
+
+	// Write through a unique temporary file so hooks never read a partial baseline and concurrent writers never share one
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write baseline; %w", err)
+	}
+	_, writeErr := file.Write(data)
+	closeErr := file.Close()
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		os.Remove(file.Name())
+		return fmt.Errorf("failed to write baseline; %w", err)
+	}
+	// The baseline is meant to be committed, so keep it readable like any other project file
+	if err := os.Chmod(file.Name(), 0644); err != nil {
+		os.Remove(file.Name())
+		return fmt.Errorf("failed to write baseline; %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		os.Remove(file.Name())
+		return fmt.Errorf("failed to write baseline; %w", err)
+	}
+
+	return nil
+}
+
+// ExpandHome expands a leading ~ to the home directory
+func ExpandHome(path string) (string, error) {
+	if len(path) > 0 && path[0] == '~' {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory; %w", err)
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	return path, nil
+}
+
 // loadEnvFiles loads environment variables from .env files
 // It tries multiple locations and fails silently if files don't exist
 func loadEnvFiles() {
@@ -5,11 +5,40 @@ import (
 	"path/filepath"
 )
 
+// DefaultBaselineName is the allowlist baseline file kept next to config.yaml
+const DefaultBaselineName = "baseline.yaml"
+
+// baselineHeader is written at the top of baseline files
+const baselineHeader = "# hook-vault-radar allowlist baseline (fingerprints only; safe to commit)\n" +
+	"# Managed with: hook-vault-radar baseline add | list | prune\n"
+
+// DefaultSocketPath is the socket "serve" listens on when daemon.socket_path is not set
+const DefaultSocketPath = "~/.agent-hooks/vault-radar/daemon.sock"
+
 // DefaultConfig provides default configuration values
 var DefaultConfig = Config{
+	Scanner: ScannerConfig{
+		Type:    "vault-radar",
+		Engines: []string{"vault-radar", "builtin"}, // Used by the composite scanner
+	},
+	Cache: CacheConfig{
+		Enabled:    false, // Disabled by default, opt-in feature
+		TTLSeconds: 3600,  // Reuse results for an hour
+	},
 	VaultRadar: VaultRadarConfig{
-		Command:        "vault-radar",
-		ScanCommand:    "scan file",
+		Command:         "vault-radar",
+		ScanCommand:     "scan file",
+		TimeoutSeconds:  30,
+		ExtraArgs:       []string{},
+		ContentTransfer: "temp_file",
+	},
+	Gitleaks: GitleaksConfig{
+		Command:        "gitleaks",
+		TimeoutSeconds: 30,
+		ExtraArgs:      []string{},
+	},
+	Trufflehog: TrufflehogConfig{
+		Command:        "trufflehog",
 		TimeoutSeconds: 30,
 		ExtraArgs:      []string{},
 	},
@@ -21,12 +50,41 @@ var DefaultConfig = Config{
 	Decision: DecisionConfig{
 		BlockOnFindings:   true,
 		SeverityThreshold: "medium",
+		Mode:              "block",
+		OnScanError:       "allow",              // Fail open by default
+		SeverityActions:   map[string]string{},  // Empty: every finding at or above the threshold denies
+		Rules:             []PolicyRule{},       // No default rules, must be configured
+		DefaultAction:     "",                   // Empty: severity_threshold and severity_actions decide
+		Overrides:         []DecisionOverride{}, // No default overrides, must be configured
+	},
+	Allowlist: AllowlistConfig{
+		Entries: []AllowlistEntry{}, // No default entries, must be configured
 	},
 	Remediation: RemediationConfig{
 		Enabled:        false,              // Disabled by default, opt-in feature
 		TimeoutSeconds: 10,                 // 10 second timeout for all remediation strategies
 		Protocols:      []ProtocolConfig{}, // No default protocols, must be configured
 	},
+	Generic: GenericConfig{
+		EventField: "hook_event_name",
+		Events:     []GenericEventConfig{}, // No default events, must be configured
+		Output: GenericOutputConfig{
+			Allow: `{"decision":"allow"}`,
+			Block: `{"decision":"block","reason":{{json .Reason}}}`,
+		},
+		ExitCodes: GenericExitCodes{
+			Allow: 0,
+			Block: 0,
+		},
+	},
+	Daemon: DaemonConfig{
+		SocketPath:     "", // Empty: hooks do not forward to a daemon, opt-in feature
+		TimeoutSeconds: 60, // Longer than a vault-radar scan
+	},
+	Project: ProjectConfig{
+		Enabled:        true,
+		AllowLoosening: false, // Projects can only tighten the policy
+	},
 }
 
 // GetDefaultConfigDir returns the default configuration directory
@@ -2,10 +2,31 @@ package config
 
 // Config represents the application configuration
 type Config struct {
+	Scanner     ScannerConfig      `mapstructure:"scanner" yaml:"scanner"`
+	Cache       CacheConfig        `mapstructure:"cache" yaml:"cache"`
 	VaultRadar  VaultRadarConfig   `mapstructure:"vault_radar" yaml:"vault_radar"`
+	Gitleaks    GitleaksConfig     `mapstructure:"gitleaks" yaml:"gitleaks"`
+	Trufflehog  TrufflehogConfig   `mapstructure:"trufflehog" yaml:"trufflehog"`
 	Logging     LoggingConfig      `mapstructure:"logging" yaml:"logging"`
 	Decision    DecisionConfig     `mapstructure:"decision" yaml:"decision"`
+	Allowlist   AllowlistConfig    `mapstructure:"allowlist" yaml:"allowlist"`
 	Remediation RemediationConfig  `mapstructure:"remediation" yaml:"remediation"`
+	Generic     GenericConfig      `mapstructure:"generic" yaml:"generic"`
+	Daemon      DaemonConfig       `mapstructure:"daemon" yaml:"daemon"`
+	Project     ProjectConfig      `mapstructure:"project" yaml:"project"`
+}
+
+// ScannerConfig selects the secret scanner implementation
+type ScannerConfig struct {
+	Type    string   `mapstructure:"type" yaml:"type"`       // "vault-radar", "builtin", "gitleaks", "trufflehog" or "composite"
+	Engines []string `mapstructure:"engines" yaml:"engines"` // Scanners run by the composite scanner, in order of precedence
+}
+
+// CacheConfig contains configuration for the scan result cache
+type CacheConfig struct {
+	Enabled    bool   `mapstructure:"enabled" yaml:"enabled"`
+	Dir        string `mapstructure:"dir" yaml:"dir"`                 // Cache directory (default: <config dir>/cache)
+	TTLSeconds int    `mapstructure:"ttl_seconds" yaml:"ttl_seconds"` // How long a cached result is reused
 }
 
 // VaultRadarConfig contains configuration for the Vault Radar CLI
@@ -14,6 +35,25 @@ type VaultRadarConfig struct {
 	ScanCommand    string   `mapstructure:"scan_command" yaml:"scan_command"`
 	TimeoutSeconds int      `mapstructure:"timeout_seconds" yaml:"timeout_seconds"`
 	ExtraArgs      []string `mapstructure:"extra_args" yaml:"extra_args"`
+
+	// ContentTransfer selects how content reaches vault-radar: "temp_file", "pipe" or "private_dir"
+	ContentTransfer string `mapstructure:"content_transfer" yaml:"content_transfer"`
+	ScanDir         string `mapstructure:"scan_dir" yaml:"scan_dir"` // Directory for private_dir transfers (default: <config dir>/scan)
+}
+
+// GitleaksConfig contains configuration for the gitleaks CLI
+type GitleaksConfig struct {
+	Command        string   `mapstructure:"command" yaml:"command"`
+	ConfigPath     string   `mapstructure:"config_path" yaml:"config_path"` // Optional gitleaks rules file (--config)
+	TimeoutSeconds int      `mapstructure:"timeout_seconds" yaml:"timeout_seconds"`
+	ExtraArgs      []string `mapstructure:"extra_args" yaml:"extra_args"`
+}
+
+// TrufflehogConfig contains configuration for the TruffleHog CLI
+type TrufflehogConfig struct {
+	Command        string   `mapstructure:"command" yaml:"command"`
+	TimeoutSeconds int      `mapstructure:"timeout_seconds" yaml:"timeout_seconds"`
+	ExtraArgs      []string `mapstructure:"extra_args" yaml:"extra_args"`
 }
 
 // LoggingConfig contains logging configuration
@@ -27,6 +67,50 @@ type LoggingConfig struct {
 type DecisionConfig struct {
 	BlockOnFindings   bool   `mapstructure:"block_on_findings" yaml:"block_on_findings"`
 	SeverityThreshold string `mapstructure:"severity_threshold" yaml:"severity_threshold"`
+	Mode              string `mapstructure:"mode" yaml:"mode"`                   // "block" or "redact" (block and offer a redacted copy)
+	OnScanError       string `mapstructure:"on_scan_error" yaml:"on_scan_error"` // "allow", "block" or "ask" when the scan cannot complete
+
+	// SeverityActions maps a finding severity to "allow", "ask" or "deny"; unmapped severities deny
+	SeverityActions map[string]string `mapstructure:"severity_actions" yaml:"severity_actions"`
+
+	// Rules are evaluated in order for each finding; the first matching rule sets its action
+	Rules         []PolicyRule `mapstructure:"rules" yaml:"rules"`
+	DefaultAction string       `mapstructure:"default_action" yaml:"default_action"` // Action for findings no rule matches (default: severity_threshold and severity_actions decide)
+
+	// Overrides adjust the settings above for matching hooks; more specific matches win
+	Overrides []DecisionOverride `mapstructure:"overrides" yaml:"overrides"`
+}
+
+// DecisionOverride sets decision settings for a framework, hook type or tool
+// Unset fields keep the value of a less specific override or of the decision section
+type DecisionOverride struct {
+	Match             string   `mapstructure:"match" yaml:"match"` // "framework[.hook_type[.tool_name]]"; "*" matches any value
+	SeverityThreshold string   `mapstructure:"severity_threshold" yaml:"severity_threshold"`
+	BlockOnFindings   *bool    `mapstructure:"block_on_findings" yaml:"block_on_findings"`
+	IncludeTypes      []string `mapstructure:"include_types" yaml:"include_types"` // Finding type globs acted on; other types are ignored
+	ExcludeTypes      []string `mapstructure:"exclude_types" yaml:"exclude_types"` // Finding type globs ignored
+}
+
+// PolicyRule sets the action for the findings its expression matches
+type PolicyRule struct {
+	Name   string `mapstructure:"name" yaml:"name"`     // Recorded in the decision metadata and reason
+	When   string `mapstructure:"when" yaml:"when"`     // Expression over finding, hook_type, tool_name, cwd and framework
+	Action string `mapstructure:"action" yaml:"action"` // "allow", "ask" or "deny"
+}
+
+// AllowlistConfig contains the known-safe findings that never trigger a decision
+type AllowlistConfig struct {
+	BaselinePath string           `mapstructure:"baseline_path" yaml:"baseline_path"` // Optional YAML or JSON file with more entries under "entries"
+	Entries      []AllowlistEntry `mapstructure:"entries" yaml:"entries"`
+}
+
+// AllowlistEntry suppresses the findings matched by every matcher it sets (fingerprint, pattern, path)
+type AllowlistEntry struct {
+	Fingerprint   string `mapstructure:"fingerprint" yaml:"fingerprint,omitempty"`     // Finding fingerprint ("sha256:..."), as logged for each finding
+	Pattern       string `mapstructure:"pattern" yaml:"pattern,omitempty"`             // Regular expression matched against the secret value
+	Path          string `mapstructure:"path" yaml:"path,omitempty"`                   // Glob matched against the finding location ("**" spans directories)
+	Expires       string `mapstructure:"expires" yaml:"expires,omitempty"`             // Optional last day (YYYY-MM-DD) or RFC 3339 time the entry applies
+	Justification string `mapstructure:"justification" yaml:"justification,omitempty"` // Why the finding is safe; recorded with each suppression
 }
 
 // RemediationConfig contains configuration for remediation actions
@@ -56,3 +140,50 @@ type StrategyConfig struct {
 	Type   string         `mapstructure:"type" yaml:"type"`
 	Config map[string]any `mapstructure:"config" yaml:"config"`
 }
+
+// GenericConfig declares the hook protocol of an agent handled by the generic framework
+// Selectors are gjson-style dot paths into the hook JSON (e.g. "tool_info.edits.#.new_string")
+type GenericConfig struct {
+	EventField     string               `mapstructure:"event_field" yaml:"event_field"`           // Selector for the event name
+	SessionIDField string               `mapstructure:"session_id_field" yaml:"session_id_field"` // Optional selector for the session ID
+	Events         []GenericEventConfig `mapstructure:"events" yaml:"events"`
+	Output         GenericOutputConfig  `mapstructure:"output" yaml:"output"`
+	ExitCodes      GenericExitCodes     `mapstructure:"exit_codes" yaml:"exit_codes"`
+
+	// Profiles declare further agents, selected with --framework generic:<name>; unset fields are taken from this block
+	Profiles map[string]GenericConfig `mapstructure:"profiles" yaml:"profiles,omitempty"`
+}
+
+// GenericEventConfig declares how to scan a single hook event
+type GenericEventConfig struct {
+	Name          string   `mapstructure:"name" yaml:"name"`                       // Event name as selected by event_field
+	Fields        []string `mapstructure:"fields" yaml:"fields"`                   // Selectors whose string values are scanned
+	FilePathField string   `mapstructure:"file_path_field" yaml:"file_path_field"` // Optional selector for the target file path
+	AuditOnly     bool     `mapstructure:"audit_only" yaml:"audit_only"`           // The agent cannot block this event
+}
+
+// GenericOutputConfig holds Go text/template output templates for each action
+type GenericOutputConfig struct {
+	Allow string `mapstructure:"allow" yaml:"allow"`
+	Block string `mapstructure:"block" yaml:"block"`
+	Ask   string `mapstructure:"ask" yaml:"ask"` // Optional; ask decisions use the block template when empty
+}
+
+// GenericExitCodes holds the process exit code for each action
+type GenericExitCodes struct {
+	Allow int `mapstructure:"allow" yaml:"allow"`
+	Block int `mapstructure:"block" yaml:"block"`
+	Ask   int `mapstructure:"ask" yaml:"ask"` // Only used when an ask output template is configured
+}
+
+// DaemonConfig contains configuration for the hook daemon (hook-vault-radar serve)
+type DaemonConfig struct {
+	SocketPath     string `mapstructure:"socket_path" yaml:"socket_path"`         // Unix socket of the daemon; empty disables forwarding
+	TimeoutSeconds int    `mapstructure:"timeout_seconds" yaml:"timeout_seconds"` // How long a hook waits for the daemon before processing in-process
+}
+
+// ProjectConfig controls the .vault-radar-hook.yaml files projects layer over this configuration
+type ProjectConfig struct {
+	Enabled        bool `mapstructure:"enabled" yaml:"enabled"`                 // Look up a project file from the hook's working directory
+	AllowLoosening bool `mapstructure:"allow_loosening" yaml:"allow_loosening"` // Let projects weaken decision settings, allowlist findings and add scanner arguments
+}
@@ -0,0 +1,90 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/processor"
+)
+
+// dialTimeout bounds connecting to the daemon; a local socket either answers at once or not at all
+const dialTimeout = time.Second
+
+// ErrUnavailable is returned by Forward when no daemon answered; nothing has been written and the
+// hook should be processed in-process instead
+var ErrUnavailable = errors.New("hook daemon unavailable")
+
+// Forward sends a hook invocation to the daemon at cfg.Daemon.SocketPath and relays its output
+// A daemon running with a different configuration rejects the request, so it is processed in-process
+// A non-zero framework exit code is returned as a *processor.ExitError, like processor.Process
+func Forward(cfg *config.Config, frameworkName string, input []byte, stdout, stderr io.Writer) error {
+	if cfg.Daemon.SocketPath == "" {
+		return ErrUnavailable
+	}
+
+	req := Request{Framework: frameworkName, Input: input, Config: configFingerprint(cfg)}
+	resp, err := roundTrip(cfg.Daemon, req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+
+	if resp.Rejected != "" {
+		return fmt.Errorf("%w: %s", ErrUnavailable, resp.Rejected)
+	}
+
+	if resp.Error != "" {
+		return errors.New(resp.Error)
+	}
+
+	if _, err := stdout.Write(resp.Stdout); err != nil {
+		return fmt.Errorf("failed to write output; %w", err)
+	}
+	if _, err := stderr.Write(resp.Stderr); err != nil {
+		return fmt.Errorf("failed to write error output; %w", err)
+	}
+
+	if resp.ExitCode != 0 {
+		return &processor.ExitError{Code: resp.ExitCode}
+	}
+
+	return nil
+}
+
+// roundTrip sends one request and reads its response
+// The whole response is read before anything is relayed, so a failing daemon leaves no partial output
+func roundTrip(cfg config.DaemonConfig, req Request) (Response, error) {
+	var resp Response
+
+	socketPath, err := config.ExpandHome(cfg.SocketPath)
+	if err != nil {
+		return resp, err
+	}
+
+	conn, err := net.DialTimeout("unix", socketPath, dialTimeout)
+	if err != nil {
+		return resp, err
+	}
+	defer conn.Close()
+
+	if cfg.TimeoutSeconds > 0 {
+		req.Deadline = time.Now().Add(time.Duration(cfg.TimeoutSeconds) * time.Second)
+		if err := conn.SetDeadline(req.Deadline); err != nil {
+			return resp, err
+		}
+	}
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return resp, fmt.Errorf("failed to send request; %w", err)
+	}
+
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return resp, fmt.Errorf("failed to read response; %w", err)
+	}
+
+	return resp, nil
+}
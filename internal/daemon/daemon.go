@@ -0,0 +1,39 @@
+package daemon
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+)
+
+// Request is a hook invocation forwarded by a client
+type Request struct {
+	Framework string    `json:"framework"`         // Empty to detect the framework from the input
+	Input     []byte    `json:"input"`             // Raw hook payload
+	Config    string    `json:"config"`            // Fingerprint of the client's configuration; a daemon with another one rejects the request
+	Deadline  time.Time `json:"deadline,omitzero"` // When the client stops waiting; processing is cancelled then
+}
+
+// Response carries the hook output back to the client
+type Response struct {
+	Stdout   []byte `json:"stdout"`
+	Stderr   []byte `json:"stderr,omitempty"`
+	ExitCode int    `json:"exit_code,omitempty"`
+	Error    string `json:"error,omitempty"`    // Processing error; the client reports it as its own
+	Rejected string `json:"rejected,omitempty"` // Why the request was not processed; the client processes it in-process
+}
+
+// configFingerprint hashes the configuration a hook is processed with, so a client and a daemon
+// loaded from different files or flags can tell they disagree
+// The daemon section only affects forwarding and is left out
+func configFingerprint(cfg *config.Config) string {
+	c := *cfg
+	c.Daemon = config.DaemonConfig{}
+	data, _ := json.Marshal(c)
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
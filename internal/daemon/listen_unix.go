@@ -0,0 +1,17 @@
+//go:build unix
+
+package daemon
+
+import (
+	"net"
+	"syscall"
+)
+
+// listenUnix creates the socket with a 0177 umask so it is never reachable by other users
+// The umask is process-wide, so files created concurrently are at most more restrictive
+func listenUnix(socketPath string) (net.Listener, error) {
+	old := syscall.Umask(0177)
+	defer syscall.Umask(old)
+
+	return net.Listen("unix", socketPath)
+}
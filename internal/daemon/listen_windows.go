@@ -0,0 +1,10 @@
+//go:build windows
+
+package daemon
+
+import "net"
+
+// listenUnix creates the socket; Windows has no umask, so access follows the directory's ACL
+func listenUnix(socketPath string) (net.Listener, error) {
+	return net.Listen("unix", socketPath)
+}
@@ -0,0 +1,285 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/processor"
+)
+
+// requestReadTimeout bounds how long a client may take to send its request
+const requestReadTimeout = 10 * time.Second
+
+// Causes of a cancelled request
+var (
+	errShutdown     = errors.New("daemon shutting down")
+	errDisconnected = errors.New("client disconnected")
+	errDeadline     = errors.New("client deadline exceeded")
+	errConfig       = errors.New("client configuration differs from the daemon's")
+)
+
+// LoadFunc builds a processor from the current configuration
+type LoadFunc func() (*processor.Processor, error)
+
+// Server processes hook invocations with a warm processor listening on a Unix domain socket
+type Server struct {
+	socketPath string
+	load       LoadFunc
+
+	// mu guards proc; it is only held to take a reference, so a reload never waits for a running scan
+	mu   sync.Mutex
+	proc *loadedProcessor
+
+	wg      sync.WaitGroup // In-flight requests
+	closing sync.WaitGroup // Replaced processors waiting for their last request
+}
+
+// loadedProcessor counts the requests using a processor, so a replaced one is closed after the last of them
+type loadedProcessor struct {
+	*processor.Processor
+	refs sync.WaitGroup
+}
+
+// NewServer creates a new daemon server with a processor built by load
+// load is called again on every Reload
+func NewServer(socketPath string, load LoadFunc) (*Server, error) {
+	socketPath, err := config.ExpandHome(socketPath)
+	if err != nil {
+		return nil, err
+	}
+	if socketPath == "" {
+		return nil, fmt.Errorf("no daemon socket path configured")
+	}
+
+	proc, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		socketPath: socketPath,
+		load:       load,
+		proc:       &loadedProcessor{Processor: proc},
+	}, nil
+}
+
+// SocketPath returns the path of the Unix socket the server listens on
+func (s *Server) SocketPath() string {
+	return s.socketPath
+}
+
+// Serve accepts hook invocations until ctx is cancelled, then waits for in-flight requests and removes the socket
+func (s *Server) Serve(ctx context.Context) error {
+	listener, err := listen(s.socketPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(s.socketPath)
+
+	s.logger().Info("daemon listening", "socket", s.socketPath)
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	var acceptErr error
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() == nil {
+				acceptErr = fmt.Errorf("failed to accept connection; %w", err)
+			}
+			break
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(ctx, conn)
+		}()
+	}
+
+	// Graceful shutdown: wait for in-flight hooks, whose scans are cancelled, before releasing the processors
+	s.wg.Wait()
+	s.closing.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.proc.Logger().Info("daemon stopped", "socket", s.socketPath)
+	s.proc.Close()
+
+	return acceptErr
+}
+
+// Reload rebuilds the processor, e.g. after the configuration changed
+// The previous processor keeps serving if the new one cannot be built; otherwise in-flight hooks finish on it
+// and it is closed after the last of them
+func (s *Server) Reload() error {
+	proc, err := s.load()
+	if err != nil {
+		s.logger().Error("failed to reload daemon configuration", "error", err)
+		return err
+	}
+
+	s.mu.Lock()
+	previous := s.proc
+	s.proc = &loadedProcessor{Processor: proc}
+	s.closing.Add(1)
+	s.mu.Unlock()
+
+	// No request takes a reference to previous once it is replaced
+	go func() {
+		defer s.closing.Done()
+		previous.refs.Wait()
+		previous.Close()
+	}()
+	proc.Logger().Info("daemon configuration reloaded")
+
+	return nil
+}
+
+// acquire returns the current processor and a function releasing it once the request is done
+func (s *Server) acquire() (*loadedProcessor, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	proc := s.proc
+	proc.refs.Add(1)
+	return proc, proc.refs.Done
+}
+
+// handle processes one request
+// Processing is cancelled when the server shuts down, the client disconnects or the request deadline passes
+func (s *Server) handle(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	proc, release := s.acquire()
+	defer release()
+
+	logger := proc.Logger()
+
+	var req Request
+	conn.SetReadDeadline(time.Now().Add(requestReadTimeout))
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		logger.Error("failed to read daemon request", "error", err)
+		return
+	}
+
+	resp := Response{}
+
+	// The client's --config, --log-level or --log-format would otherwise be ignored
+	if req.Config != configFingerprint(proc.Config()) {
+		logger.Warn("daemon request rejected", "error", errConfig)
+		resp.Rejected = errConfig.Error()
+		respond(conn, resp, logger)
+		return
+	}
+
+	ctx, cancel := requestContext(ctx, conn, req)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	err := proc.ProcessHook(ctx, bytes.NewReader(req.Input), &stdout, &stderr, req.Framework)
+
+	var exitErr *processor.ExitError
+	switch {
+	case ctx.Err() != nil:
+		// A cut-short scan must not decide the hook; a client still waiting processes it in-process
+		logger.Warn("daemon request cancelled", "error", context.Cause(ctx))
+		resp.Rejected = context.Cause(ctx).Error()
+	case errors.As(err, &exitErr):
+		resp.ExitCode = exitErr.Code
+	case err != nil:
+		resp.Error = err.Error()
+	}
+	if resp.Rejected == "" {
+		resp.Stdout = stdout.Bytes()
+		resp.Stderr = stderr.Bytes()
+	}
+
+	respond(conn, resp, logger)
+}
+
+// respond writes the response of a request
+func respond(conn net.Conn, resp Response, logger *slog.Logger) {
+	conn.SetWriteDeadline(time.Now().Add(requestReadTimeout))
+	if err := json.NewEncoder(conn).Encode(resp); err != nil {
+		logger.Error("failed to write daemon response", "error", err)
+	}
+}
+
+// requestContext derives the context of a request from the server context and the request deadline
+// It is also cancelled when the client closes the connection; the cause tells which happened
+func requestContext(ctx context.Context, conn net.Conn, req Request) (context.Context, context.CancelFunc) {
+	reqCtx, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+	stopShutdown := context.AfterFunc(ctx, func() { cancel(errShutdown) })
+
+	cancelDeadline := context.CancelFunc(func() {})
+	if !req.Deadline.IsZero() {
+		reqCtx, cancelDeadline = context.WithDeadlineCause(reqCtx, req.Deadline, errDeadline)
+	}
+
+	// The client sends nothing after its request, so a read only returns once the connection is closed
+	conn.SetReadDeadline(time.Time{})
+	go func() {
+		io.Copy(io.Discard, conn)
+		cancel(errDisconnected)
+	}()
+
+	return reqCtx, func() {
+		stopShutdown()
+		cancelDeadline()
+		cancel(context.Canceled)
+	}
+}
+
+// logger returns the logger of the current processor
+func (s *Server) logger() *slog.Logger {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.proc.Logger()
+}
+
+// listen creates the Unix socket, readable and writable by the owner only
+// A socket left behind by a daemon that did not shut down cleanly is replaced; a live one is an error
+func listen(socketPath string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(socketPath), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create socket directory; %w", err)
+	}
+
+	if conn, err := net.DialTimeout("unix", socketPath, dialTimeout); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("a daemon is already listening on %s", socketPath)
+	}
+
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove stale socket; %w", err)
+	}
+
+	listener, err := listenUnix(socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s; %w", socketPath, err)
+	}
+
+	// The umask already restricts the socket; chmod also covers platforms without one
+	if err := os.Chmod(socketPath, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to secure socket; %w", err)
+	}
+
+	return listener, nil
+}
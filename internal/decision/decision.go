@@ -2,72 +2,303 @@ package decision
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/allowlist"
 	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/policy"
 	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
 )
 
+// Scan error policies
+const (
+	OnScanErrorAllow = "allow" // Fail open
+	OnScanErrorBlock = "block" // Fail closed
+	OnScanErrorAsk   = "ask"   // Ask the human to confirm
+)
+
+// Decision modes
+const (
+	ModeBlock  = "block"  // Block the action
+	ModeRedact = "redact" // Block the action and offer a copy of the content with secrets masked
+)
+
 // Engine makes decisions based on scan results and configuration
 type Engine struct {
-	cfg *config.Config
+	cfg          *config.Config
+	allowlist    *allowlist.List
+	allowlistErr error // Problems loading the allowlist; see AllowlistError
+	policy       *policy.Policy
+	overrides    []override
+	policyErr    error  // Problems compiling the policy rules and overrides; see PolicyError
+	mode         string // Normalized decision.mode
+	onScanError  string // Normalized decision.on_scan_error
+	settingsErr  error  // Invalid decision settings; see SettingsError
 }
 
 // NewEngine creates a new decision engine
 func NewEngine(cfg *config.Config) *Engine {
+	list, err := allowlist.New(cfg.Allowlist)
+	rules, rulesErr := policy.New(cfg.Decision.Rules)
+	overrides, overridesErr := compileOverrides(cfg.Decision.Overrides)
+	mode, modeErr := decisionMode(cfg.Decision.Mode)
+	onScanError, onScanErrorErr := scanErrorPolicy(cfg.Decision.OnScanError)
+
 	return &Engine{
-		cfg: cfg,
+		cfg:          cfg,
+		allowlist:    list,
+		allowlistErr: err,
+		policy:       rules,
+		overrides:    overrides,
+		policyErr:    errors.Join(rulesErr, overridesErr),
+		mode:         mode,
+		onScanError:  onScanError,
+		settingsErr:  errors.Join(modeErr, onScanErrorErr),
+	}
+}
+
+// PolicyError returns the problems found while compiling the policy rules and overrides, or nil
+// Invalid rules and overrides are skipped; the remaining ones still apply
+func (e *Engine) PolicyError() error {
+	return e.policyErr
+}
+
+// SettingsError returns the invalid decision settings, or nil
+// Invalid values are replaced by the fail-closed choice
+func (e *Engine) SettingsError() error {
+	return e.settingsErr
+}
+
+// decisionMode normalizes decision.mode
+// An empty value blocks; unknown values block and are reported
+func decisionMode(value string) (string, error) {
+	switch mode := strings.ToLower(value); mode {
+	case "":
+		return ModeBlock, nil
+	case ModeBlock, ModeRedact:
+		return mode, nil
+	default:
+		return ModeBlock, fmt.Errorf("invalid decision.mode %q (expected block or redact); blocking without redaction", value)
+	}
+}
+
+// scanErrorPolicy normalizes decision.on_scan_error, accepting "deny" for "block"
+// An empty value fails open; unknown values fail closed and are reported
+func scanErrorPolicy(value string) (string, error) {
+	switch policy := strings.ToLower(value); policy {
+	case "", OnScanErrorAllow:
+		return OnScanErrorAllow, nil
+	case OnScanErrorBlock, OnScanErrorAsk:
+		return policy, nil
+	case types.ActionDeny:
+		return OnScanErrorBlock, nil
+	default:
+		return OnScanErrorBlock, fmt.Errorf("invalid decision.on_scan_error %q (expected allow, block or ask); blocking when the scan fails", value)
 	}
 }
 
-// Evaluate evaluates scan results and produces a decision
-func (e *Engine) Evaluate(ctx context.Context, results types.ScanResults) (types.Decision, error) {
+// Evaluate evaluates scan results for the content scanned for a hook invocation and produces a decision
+func (e *Engine) Evaluate(ctx context.Context, input types.HookInput, content types.ScanContent, results types.ScanResults) (types.Decision, error) {
 	decision := types.Decision{
 		Block:    false,
+		Action:   types.ActionAllow,
 		Metadata: make(map[string]any),
 	}
 
-	// If there was an error during scanning, decide based on fail-open/fail-closed policy
+	// Overrides for the framework, hook type and tool replace the configured thresholds
+	s := e.resolveSettings(input, content.Metadata["tool_name"])
+	if len(s.overrides) > 0 {
+		decision.Metadata["decision_overrides"] = s.overrides
+	}
+
+	// Known-safe findings are recorded but never acted on
+	results = e.ApplyAllowlist(content, results)
+	findings, suppressed := splitSuppressed(results.Findings)
+	if len(suppressed) > 0 {
+		decision.Metadata["suppressed_findings"] = suppressed
+		decision.Metadata["suppressed_count"] = len(suppressed)
+	}
+
+	if results.CacheHits > 0 {
+		decision.Metadata["cache_hits"] = results.CacheHits
+	}
+
 	if results.Error != nil {
 		decision.Metadata["scan_error"] = results.Error.Error()
-		// Currently fail-open (allow on error), but this could be configurable
-		return decision, nil
+		decision.Metadata["scan_error_kind"] = scanErrorKind(results.Error)
 	}
 
-	if !results.HasFindings {
-		return decision, nil
+	// Findings are acted on even when part of the scan failed (e.g. one engine of a composite scanner)
+	if results.HasFindings {
+		e.evaluateFindings(&decision, s, input, content, findings)
 	}
 
-	// Filter findings by severity threshold
-	relevantFindings := e.filterBySeverity(results.Findings)
+	// If there was an error during scanning and the findings did not already stop the action,
+	// decide based on fail-open/fail-closed policy
+	if results.Error != nil && decision.Action == types.ActionAllow {
+		e.applyScanErrorPolicy(&decision, results.Error)
+	}
+
+	return decision, nil
+}
+
+// evaluateFindings sets the decision for the findings of a scan
+func (e *Engine) evaluateFindings(decision *types.Decision, s settings, input types.HookInput, content types.ScanContent, findings []types.Finding) {
+	var relevantFindings []types.Finding
+	var rules []string
+	var action string
+
+	// Findings of types the hook ignores are treated like findings below the threshold
+	candidates := s.filterTypes(findings)
+
+	if e.policy.Len() > 0 {
+		var rule string
+		relevantFindings, rules, action, rule = e.applyPolicy(s, input, content, candidates)
+		if rule != "" {
+			decision.Metadata["policy_rule"] = rule
+		}
+	} else {
+		// Filter findings by severity threshold
+		relevantFindings = e.filterBySeverity(candidates, s.severityThreshold)
+		action = e.resolveAction(relevantFindings)
+	}
 
 	if len(relevantFindings) == 0 {
 		// No findings meet the threshold
-		decision.Metadata["filtered_findings"] = results.Findings
-		return decision, nil
+		decision.Metadata["filtered_findings"] = findings
+		return
 	}
 
-	// Block if configured to do so and we have relevant findings
-	if e.cfg.Decision.BlockOnFindings {
-		decision.Block = true
-		decision.Reason = e.buildReasonMessage(relevantFindings)
+	// Block (or ask) if configured to do so and we have relevant findings
+	if s.blockOnFindings {
+		decision.Action = action
+		decision.Block = decision.Action == types.ActionDeny
+		decision.Reason = e.buildReasonMessage(relevantFindings, rules)
 		decision.Metadata["findings"] = relevantFindings
 		decision.Metadata["finding_count"] = len(relevantFindings)
+
+		if decision.Block && e.mode == ModeRedact {
+			e.applyRedaction(decision, content, relevantFindings)
+		}
 	}
+}
 
-	return decision, nil
+// applyPolicy resolves the action of each finding with the policy rules
+// It returns the findings that are not allowed with the rule that decided each of them,
+// and the most restrictive action with the rule that set it (empty when no rule was involved)
+func (e *Engine) applyPolicy(s settings, input types.HookInput, content types.ScanContent, findings []types.Finding) ([]types.Finding, []string, string, string) {
+	env := policy.Env{
+		HookType:  input.HookType,
+		ToolName:  content.Metadata["tool_name"],
+		CWD:       content.Metadata["cwd"],
+		Framework: input.Framework,
+	}
+
+	relevant := []types.Finding{}
+	var rules []string
+	action, decisive := types.ActionAllow, ""
+	level := -1
+
+	for _, finding := range findings {
+		env.Finding = finding
+		findingAction, rule, ok := e.policyAction(env, s.severityThreshold)
+		if !ok {
+			continue
+		}
+
+		if findingAction != types.ActionAllow {
+			relevant = append(relevant, finding)
+			rules = append(rules, rule)
+		}
+		if ActionLevel(findingAction) > level {
+			level = ActionLevel(findingAction)
+			action, decisive = findingAction, rule
+		}
+	}
+
+	return relevant, rules, action, decisive
+}
+
+// policyAction returns the action and deciding rule for one finding: the first matching rule,
+// then decision.default_action, then the severity threshold and severity actions
+// ok is false for findings below the severity threshold
+func (e *Engine) policyAction(env policy.Env, threshold string) (action, rule string, ok bool) {
+	if matched, found := e.policy.Match(env); found {
+		return matched.Action, matched.Name, true
+	}
+
+	if e.cfg.Decision.DefaultAction != "" {
+		return EffectiveAction(e.cfg.Decision.DefaultAction), policy.DefaultRule, true
+	}
+
+	if len(e.filterBySeverity([]types.Finding{env.Finding}, threshold)) == 0 {
+		return "", "", false
+	}
+	return e.resolveAction([]types.Finding{env.Finding}), "", true
+}
+
+// applyScanErrorPolicy sets the decision for a scan that could not complete
+func (e *Engine) applyScanErrorPolicy(decision *types.Decision, scanErr error) {
+	policy := e.onScanError
+
+	var outcome string
+	switch policy {
+	case OnScanErrorBlock:
+		decision.Action = types.ActionDeny
+		decision.Block = true
+		outcome = "so this action was blocked"
+	case OnScanErrorAsk:
+		decision.Action = types.ActionAsk
+		outcome = "so this action requires confirmation"
+	default:
+		// Fail open
+		return
+	}
+
+	decision.Metadata["scan_error_policy"] = policy
+	decision.Reason = "\nVault Radar could not complete the security scan: " + scanErr.Error() + "\n\n" +
+		"The content could not be checked for secrets, " + outcome +
+		" (decision.on_scan_error: " + policy + ")."
+}
+
+// scanErrorKind returns the kind of a scan error, treating unclassified errors as execution failures
+func scanErrorKind(err error) string {
+	var scanErr *types.ScanError
+	if errors.As(err, &scanErr) && scanErr.Kind != "" {
+		return scanErr.Kind
+	}
+	return types.ScanErrorExecution
 }
 
-// filterBySeverity filters findings based on the configured severity threshold
-func (e *Engine) filterBySeverity(findings []types.Finding) []types.Finding {
-	threshold := e.getSeverityLevel(e.cfg.Decision.SeverityThreshold)
+// applyRedaction attaches a redacted copy of the content when every relevant finding can be masked
+func (e *Engine) applyRedaction(decision *types.Decision, content types.ScanContent, findings []types.Finding) {
+	if len(content.Parts) > 0 {
+		decision.Metadata["redaction"] = "unsupported for multi-part content"
+		return
+	}
+
+	redacted, ok := Redact(content.Content, findings)
+	if !ok {
+		// Partial redaction could leak a secret, so fall back to a plain block
+		decision.Metadata["redaction"] = "unavailable: findings without secret positions"
+		return
+	}
+
+	decision.RedactedContent = redacted
+	decision.Metadata["redaction"] = "applied"
+}
+
+// filterBySeverity filters findings based on a severity threshold
+func (e *Engine) filterBySeverity(findings []types.Finding, severityThreshold string) []types.Finding {
+	threshold := SeverityLevel(severityThreshold)
 	filtered := []types.Finding{}
 
 	for _, finding := range findings {
-		findingSeverity := e.getSeverityLevel(finding.Severity)
+		findingSeverity := SeverityLevel(finding.Severity)
 		if findingSeverity >= threshold {
 			filtered = append(filtered, finding)
 		}
@@ -76,8 +307,48 @@ func (e *Engine) filterBySeverity(findings []types.Finding) []types.Finding {
 	return filtered
 }
 
-// getSeverityLevel converts severity string to numeric level for comparison
-func (e *Engine) getSeverityLevel(severity string) int {
+// resolveAction returns the most restrictive action configured for the severities of the findings
+// Severities without a configured action deny, preserving the binary block behavior by default
+func (e *Engine) resolveAction(findings []types.Finding) string {
+	action := types.ActionAllow
+
+	for _, finding := range findings {
+		findingAction := EffectiveAction(e.cfg.Decision.SeverityActions[strings.ToLower(finding.Severity)])
+		if ActionLevel(findingAction) > ActionLevel(action) {
+			action = findingAction
+		}
+	}
+
+	return action
+}
+
+// EffectiveAction returns the action the engine takes for a configured action
+// Empty and unknown actions deny
+func EffectiveAction(action string) string {
+	action = strings.ToLower(action)
+	if ActionLevel(action) < 0 {
+		return types.ActionDeny
+	}
+	return action
+}
+
+// ActionLevel converts an action to a numeric restrictiveness level, or -1 if unknown
+func ActionLevel(action string) int {
+	switch action {
+	case types.ActionAllow:
+		return 0
+	case types.ActionAsk:
+		return 1
+	case types.ActionDeny:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// SeverityLevel converts severity string to numeric level for comparison
+// Unknown severities return 0, below every known severity
+func SeverityLevel(severity string) int {
 	switch strings.ToLower(severity) {
 	case "critical":
 		return 4
@@ -93,7 +364,8 @@ func (e *Engine) getSeverityLevel(severity string) int {
 }
 
 // buildReasonMessage creates a human-readable explanation of why the action was blocked
-func (e *Engine) buildReasonMessage(findings []types.Finding) string {
+// rules holds the policy rule that decided each finding, if any
+func (e *Engine) buildReasonMessage(findings []types.Finding, rules []string) string {
 	if len(findings) == 0 {
 		return "Security scan completed with no findings"
 	}
@@ -127,6 +399,12 @@ func (e *Engine) buildReasonMessage(findings []types.Finding) string {
 			sb.WriteString(")")
 		}
 
+		if i < len(rules) && rules[i] != "" {
+			sb.WriteString(" [rule: ")
+			sb.WriteString(rules[i])
+			sb.WriteString("]")
+		}
+
 		sb.WriteString("\n")
 	}
 
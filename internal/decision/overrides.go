@@ -0,0 +1,168 @@
+package decision
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+// matchWildcard matches any framework, hook type or tool name in an override key
+const matchWildcard = "*"
+
+// override is a validated decision override
+type override struct {
+	config.DecisionOverride
+	segments []string // Framework, hook type and tool name; missing trailing segments match anything
+}
+
+// settings are the decision settings in effect for one hook invocation
+type settings struct {
+	blockOnFindings   bool
+	severityThreshold string
+	includeTypes      []string
+	excludeTypes      []string
+	overrides         []string // Match keys of the applied overrides, least specific first
+}
+
+// compileOverrides validates the configured overrides
+// Invalid overrides are skipped and reported in the returned error
+func compileOverrides(overrides []config.DecisionOverride) ([]override, error) {
+	var compiled []override
+	var errs []error
+
+	for i, o := range overrides {
+		segments, err := validateOverride(o)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("decision override %d (%q): %w", i+1, o.Match, err))
+			continue
+		}
+		compiled = append(compiled, override{DecisionOverride: o, segments: segments})
+	}
+
+	return compiled, errors.Join(errs...)
+}
+
+// validateOverride checks the key, threshold and type globs of an override and returns its key segments
+func validateOverride(o config.DecisionOverride) ([]string, error) {
+	segments := strings.Split(o.Match, ".")
+	if len(segments) > 3 {
+		return nil, fmt.Errorf("match has more than framework, hook type and tool name")
+	}
+	for _, segment := range segments {
+		if strings.TrimSpace(segment) == "" {
+			return nil, fmt.Errorf("match has an empty segment")
+		}
+	}
+
+	if o.SeverityThreshold != "" && SeverityLevel(o.SeverityThreshold) == 0 {
+		return nil, fmt.Errorf("invalid severity_threshold %q", o.SeverityThreshold)
+	}
+
+	for _, glob := range append(o.IncludeTypes[:len(o.IncludeTypes):len(o.IncludeTypes)], o.ExcludeTypes...) {
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, fmt.Errorf("invalid finding type glob %q", glob)
+		}
+	}
+
+	return segments, nil
+}
+
+// matches reports whether the override applies to a hook invocation
+func (o override) matches(framework, hookType, toolName string) bool {
+	values := []string{framework, hookType, toolName}
+	for i, segment := range o.segments {
+		if segment != matchWildcard && !strings.EqualFold(segment, values[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Overlaps reports whether two override match keys can apply to the same hook invocation
+func Overlaps(a, b string) bool {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] != matchWildcard && bs[i] != matchWildcard && !strings.EqualFold(as[i], bs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// specificity counts the segments that name a value instead of matching anything
+func (o override) specificity() int {
+	count := 0
+	for _, segment := range o.segments {
+		if segment != matchWildcard {
+			count++
+		}
+	}
+	return count
+}
+
+// resolveSettings applies the overrides matching a hook invocation to the decision settings
+// Overrides apply from least to most specific, so the most specific one wins for each field it sets
+func (e *Engine) resolveSettings(input types.HookInput, toolName string) settings {
+	s := settings{
+		blockOnFindings:   e.cfg.Decision.BlockOnFindings,
+		severityThreshold: e.cfg.Decision.SeverityThreshold,
+	}
+
+	var matched []override
+	for _, o := range e.overrides {
+		if o.matches(input.Framework, input.HookType, toolName) {
+			matched = append(matched, o)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].specificity() < matched[j].specificity()
+	})
+
+	for _, o := range matched {
+		if o.SeverityThreshold != "" {
+			s.severityThreshold = o.SeverityThreshold
+		}
+		if o.BlockOnFindings != nil {
+			s.blockOnFindings = *o.BlockOnFindings
+		}
+		if len(o.IncludeTypes) > 0 {
+			s.includeTypes = o.IncludeTypes
+		}
+		if len(o.ExcludeTypes) > 0 {
+			s.excludeTypes = o.ExcludeTypes
+		}
+		s.overrides = append(s.overrides, o.Match)
+	}
+
+	return s
+}
+
+// filterTypes returns the findings whose types the hook acts on
+func (s settings) filterTypes(findings []types.Finding) []types.Finding {
+	if len(s.includeTypes) == 0 && len(s.excludeTypes) == 0 {
+		return findings
+	}
+
+	filtered := []types.Finding{}
+	for _, finding := range findings {
+		if (len(s.includeTypes) == 0 || matchesType(s.includeTypes, finding.Type)) && !matchesType(s.excludeTypes, finding.Type) {
+			filtered = append(filtered, finding)
+		}
+	}
+	return filtered
+}
+
+// matchesType reports whether a finding type matches any of the globs, ignoring case
+func matchesType(globs []string, findingType string) bool {
+	for _, glob := range globs {
+		if ok, _ := path.Match(strings.ToLower(glob), strings.ToLower(findingType)); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package decision
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+// Redact replaces the span of each finding in content with a "[REDACTED:<type>]" marker
+// It returns false if any finding lacks a usable span, since a partial redaction could leak a secret
+func Redact(content string, findings []types.Finding) (string, bool) {
+	if len(findings) == 0 {
+		return content, true
+	}
+
+	spans := make([]types.Finding, 0, len(findings))
+	for _, finding := range findings {
+		if !finding.HasSpan() || finding.StartOffset < 0 || finding.EndOffset > len(content) {
+			return "", false
+		}
+		spans = append(spans, finding)
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].StartOffset < spans[j].StartOffset
+	})
+
+	var sb strings.Builder
+	cursor := 0
+
+	for i := 0; i < len(spans); i++ {
+		start, end := spans[i].StartOffset, spans[i].EndOffset
+		label := spans[i].Type
+
+		// Merge overlapping spans into a single marker
+		for i+1 < len(spans) && spans[i+1].StartOffset < end {
+			i++
+			if spans[i].EndOffset > end {
+				end = spans[i].EndOffset
+			}
+		}
+
+		sb.WriteString(content[cursor:start])
+		sb.WriteString("[REDACTED:")
+		sb.WriteString(label)
+		sb.WriteString("]")
+		cursor = end
+	}
+
+	sb.WriteString(content[cursor:])
+
+	return sb.String(), true
+}
@@ -0,0 +1,96 @@
+package decision
+
+import (
+	"time"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/allowlist"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+// ApplyAllowlist fingerprints each finding and marks the findings matched by the allowlist as suppressed
+// HasFindings is recomputed so that only findings which are not suppressed count
+// Findings already marked suppressed are kept as they are, so applying the allowlist twice is harmless
+func (e *Engine) ApplyAllowlist(content types.ScanContent, results types.ScanResults) types.ScanResults {
+	if len(results.Findings) == 0 {
+		return results
+	}
+
+	now := time.Now()
+	findings := make([]types.Finding, len(results.Findings))
+	hasFindings := false
+
+	for i, finding := range results.Findings {
+		value, ok := secretValue(content, finding)
+		if ok && finding.Fingerprint == "" {
+			finding.Fingerprint = allowlist.Fingerprint(finding.Type, value)
+		}
+
+		if !finding.Suppressed {
+			if entry, matched := e.allowlist.Match(finding, value, now); matched {
+				finding.Suppressed = true
+				finding.Justification = entry.Justification
+			}
+		}
+
+		if !finding.Suppressed {
+			hasFindings = true
+		}
+		findings[i] = finding
+	}
+
+	results.Findings = findings
+	results.HasFindings = hasFindings
+	return results
+}
+
+// AllowlistError returns the problems found while loading the allowlist, or nil
+// Invalid entries are skipped; the remaining entries still apply
+func (e *Engine) AllowlistError() error {
+	return e.allowlistErr
+}
+
+// splitSuppressed separates findings that are acted on from suppressed findings
+func splitSuppressed(findings []types.Finding) (active, suppressed []types.Finding) {
+	active = []types.Finding{}
+	for _, finding := range findings {
+		if finding.Suppressed {
+			suppressed = append(suppressed, finding)
+		} else {
+			active = append(active, finding)
+		}
+	}
+	return active, suppressed
+}
+
+// secretValue returns the secret a finding points at, or false if it cannot be located
+// For multi-part content the part is identified by the finding location, set from the part's
+// "location" or "file_path" metadata by the scanners
+func secretValue(content types.ScanContent, finding types.Finding) (string, bool) {
+	if !finding.HasSpan() || finding.StartOffset < 0 {
+		return "", false
+	}
+
+	if len(content.Parts) == 0 {
+		return spanValue(content.Content, finding)
+	}
+
+	for _, part := range content.Parts {
+		location := part.Metadata["location"]
+		if location == "" {
+			location = part.Metadata["file_path"]
+		}
+		if location == finding.Location {
+			return spanValue(part.Content, finding)
+		}
+	}
+
+	return "", false
+}
+
+// spanValue returns the text covered by a finding's span
+func spanValue(content string, finding types.Finding) (string, bool) {
+	if finding.EndOffset > len(content) {
+		return "", false
+	}
+	return content[finding.StartOffset:finding.EndOffset], true
+}
@@ -0,0 +1,25 @@
+package filelock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Lock blocks until it holds an exclusive advisory lock on path, creating the file if needed
+// The returned function releases the lock; locks are also released when the process exits
+func Lock(path string) (func() error, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file; %w", err)
+	}
+
+	if err := lock(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to lock %s; %w", path, err)
+	}
+
+	return func() error {
+		return errors.Join(unlock(file), file.Close())
+	}, nil
+}
@@ -0,0 +1,49 @@
+package filelock
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.lock")
+
+	var mu sync.Mutex
+	holders, maxHolders := 0, 0
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			unlock, err := Lock(path)
+			if err != nil {
+				t.Errorf("Lock() failed: %v", err)
+				return
+			}
+
+			mu.Lock()
+			holders++
+			maxHolders = max(maxHolders, holders)
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			holders--
+			mu.Unlock()
+
+			if err := unlock(); err != nil {
+				t.Errorf("unlock failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxHolders != 1 {
+		t.Errorf("lock held by %d goroutines at once, want 1", maxHolders)
+	}
+}
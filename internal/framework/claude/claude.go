@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"path/filepath"
 
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
 	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
 	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
 )
@@ -17,7 +19,11 @@ type Framework struct {
 }
 
 // Force compile-time check for interface implementation
-var _ framework.HookFramework = (*Framework)(nil)
+var (
+	_ framework.HookFramework      = (*Framework)(nil)
+	_ framework.Detector           = (*Framework)(nil)
+	_ framework.AuditOnlyFramework = (*Framework)(nil)
+)
 
 // NewFramework creates a new Claude framework instance
 func NewFramework() *Framework {
@@ -27,6 +33,14 @@ func NewFramework() *Framework {
 
 	// Register default handlers
 	f.RegisterHandler(NewUserPromptSubmitHandler())
+	f.RegisterHandler(NewPreToolUseHandler())
+	f.RegisterHandler(NewPostToolUseHandler())
+	f.RegisterHandler(NewSessionStartHandler())
+
+	// Transcript sweeps persist per-session offsets under the config directory
+	transcriptStateDir := filepath.Join(config.GetDefaultConfigDir(), "state", "transcripts")
+	f.RegisterHandler(NewStopHandler(transcriptStateDir))
+	f.RegisterHandler(NewSubagentStopHandler(transcriptStateDir))
 
 	return f
 }
@@ -68,6 +82,20 @@ func (f *Framework) ParseInput(reader io.Reader) (types.HookInput, error) {
 	}, nil
 }
 
+// Detect returns true for payloads with a session_id and a Claude hook_event_name
+func (f *Framework) Detect(rawData map[string]any) bool {
+	hookEventName, ok := rawData["hook_event_name"].(string)
+	if !ok {
+		return false
+	}
+	if _, ok := rawData["session_id"]; !ok {
+		return false
+	}
+
+	_, err := f.GetHandler(types.HookInput{Framework: frameworkName, HookType: hookEventName})
+	return err == nil
+}
+
 // FormatOutput formats a decision as JSON for Claude Code
 func (f *Framework) FormatOutput(decision types.Decision, input types.HookInput) ([]byte, error) {
 	output := HookOutput{
@@ -75,12 +103,6 @@ func (f *Framework) FormatOutput(decision types.Decision, input types.HookInput)
 		SuppressOutput: false,
 	}
 
-	if decision.Block {
-		output.Decision = "block"
-		output.Reason = decision.Reason
-		output.SystemMessage = decision.Reason
-	}
-
 	// Add hook-specific output if available
 	if hookEventName, ok := input.RawData["hook_event_name"].(string); ok {
 		output.HookSpecificOutput = HookSpecificOutput{
@@ -88,6 +110,64 @@ func (f *Framework) FormatOutput(decision types.Decision, input types.HookInput)
 		}
 	}
 
+	// Only PreToolUse can ask the human to confirm; other hooks block rather than silently allow
+	intervene := requiresIntervention(decision)
+
+	// SessionStart cannot block; the preamble and any files containing secrets are injected as context
+	if input.HookType == sessionStartType {
+		output.HookSpecificOutput.AdditionalContext = buildSessionContext(decision)
+		if intervene {
+			output.SystemMessage = decision.Reason
+		}
+	} else if intervene {
+		switch input.HookType {
+		case preToolUseType:
+			// PreToolUse blocks (or asks) through a permission decision rather than the top-level decision field
+			output.HookSpecificOutput.PermissionDecision = types.ActionDeny
+			if decision.Action == types.ActionAsk {
+				output.HookSpecificOutput.PermissionDecision = types.ActionAsk
+			}
+			output.HookSpecificOutput.PermissionDecisionReason = decision.Reason
+			output.SystemMessage = decision.Reason
+		case postToolUseType:
+			// The tool already ran, so the reason is fed back to the model with instructions not to reuse the secret
+			output.Decision = "block"
+			output.Reason = decision.Reason
+			if framework.HasFindings(decision) {
+				output.Reason += postToolUseGuidance
+			}
+			output.SystemMessage = decision.Reason
+		case stopType, subagentStopType:
+			// A stop hook already continuing the agent is not blocked again, so the agent cannot loop;
+			// the user is still told about the findings
+			if decision.AuditOnly {
+				output.SystemMessage = decision.Reason
+				break
+			}
+
+			// Blocking a stop keeps the agent running with the reason as its next instruction
+			output.Decision = "block"
+			output.Reason = decision.Reason
+			if framework.HasFindings(decision) {
+				output.Reason += stopGuidance
+			}
+			output.SystemMessage = decision.Reason
+		case userPromptSubmitType:
+			// The original prompt is blocked; a redacted copy (redact mode) is offered for resubmission as context,
+			// keeping the reason to the explanation
+			output.Decision = "block"
+			output.Reason = decision.Reason
+			if decision.RedactedContent != "" {
+				output.HookSpecificOutput.AdditionalContext = redactedPromptNotice + decision.RedactedContent
+			}
+			output.SystemMessage = decision.Reason
+		default:
+			output.Decision = "block"
+			output.Reason = decision.Reason
+			output.SystemMessage = decision.Reason
+		}
+	}
+
 	data, err := json.Marshal(output)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal output; %w", err)
@@ -96,6 +176,24 @@ func (f *Framework) FormatOutput(decision types.Decision, input types.HookInput)
 	return data, nil
 }
 
+// requiresIntervention reports whether a decision denies the action or asks for confirmation
+func requiresIntervention(decision types.Decision) bool {
+	return decision.Block || decision.Action == types.ActionAsk
+}
+
+// IsAuditOnly reports whether the decision for the given input cannot be enforced
+// Stop and SubagentStop events with stop_hook_active set come from an agent already continuing
+// because of an earlier block, so they are allowed rather than blocked again
+func (f *Framework) IsAuditOnly(input types.HookInput) bool {
+	switch input.HookType {
+	case stopType, subagentStopType:
+		active, _ := input.RawData["stop_hook_active"].(bool)
+		return active
+	default:
+		return false
+	}
+}
+
 // GetExitCode returns the appropriate exit code for Claude Code
 // Claude Code expects exit 0 with JSON containing "continue": false to block
 // Exit code 2 would cause Claude to show only stderr, ignoring our structured JSON
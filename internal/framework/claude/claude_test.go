@@ -0,0 +1,132 @@
+package claude
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+func TestFramework_FormatOutput(t *testing.T) {
+	tests := []struct {
+		name                     string
+		hookType                 string
+		decision                 types.Decision
+		expectDecision           string
+		expectPermissionDecision string
+		expectReason             string // Checked when set
+		expectContext            string
+	}{
+		{
+			name:     "pretooluse allow",
+			hookType: preToolUseType,
+			decision: types.Decision{Action: types.ActionAllow},
+		},
+		{
+			name:                     "pretooluse ask",
+			hookType:                 preToolUseType,
+			decision:                 types.Decision{Action: types.ActionAsk, Reason: "found"},
+			expectPermissionDecision: "ask",
+		},
+		{
+			name:                     "pretooluse deny",
+			hookType:                 preToolUseType,
+			decision:                 types.Decision{Block: true, Action: types.ActionDeny, Reason: "found"},
+			expectPermissionDecision: "deny",
+		},
+		{
+			name:           "userpromptsubmit ask falls back to block",
+			hookType:       userPromptSubmitType,
+			decision:       types.Decision{Action: types.ActionAsk, Reason: "found"},
+			expectDecision: "block",
+		},
+		{
+			name:           "userpromptsubmit redact offers the redacted prompt as context",
+			hookType:       userPromptSubmitType,
+			decision:       types.Decision{Block: true, Action: types.ActionDeny, Reason: "found", RedactedContent: "key [REDACTED:aws_access_key_id]"},
+			expectDecision: "block",
+			expectReason:   "found",
+			expectContext:  redactedPromptNotice + "key [REDACTED:aws_access_key_id]",
+		},
+		{
+			name:           "posttooluse deny",
+			hookType:       postToolUseType,
+			decision:       types.Decision{Block: true, Action: types.ActionDeny, Reason: "found"},
+			expectDecision: "block",
+		},
+		{
+			name:           "stop deny",
+			hookType:       stopType,
+			decision:       types.Decision{Block: true, Action: types.ActionDeny, Reason: "found"},
+			expectDecision: "block",
+		},
+		{
+			name:     "stop deny while the stop hook is active",
+			hookType: stopType,
+			decision: types.Decision{Block: true, Action: types.ActionDeny, Reason: "found", AuditOnly: true},
+		},
+	}
+
+	f := NewFramework()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := types.HookInput{
+				Framework: frameworkName,
+				HookType:  tt.hookType,
+				RawData:   map[string]any{"hook_event_name": tt.hookType},
+			}
+
+			data, err := f.FormatOutput(tt.decision, input)
+			if err != nil {
+				t.Fatalf("FormatOutput() failed: %v", err)
+			}
+
+			var output HookOutput
+			if err := json.Unmarshal(data, &output); err != nil {
+				t.Fatalf("invalid JSON output: %v", err)
+			}
+
+			if output.Decision != tt.expectDecision {
+				t.Errorf("decision = %q, want %q", output.Decision, tt.expectDecision)
+			}
+			if output.HookSpecificOutput.PermissionDecision != tt.expectPermissionDecision {
+				t.Errorf("permissionDecision = %q, want %q", output.HookSpecificOutput.PermissionDecision, tt.expectPermissionDecision)
+			}
+			if tt.expectPermissionDecision != "" && output.HookSpecificOutput.PermissionDecisionReason == "" {
+				t.Error("expected permissionDecisionReason to be set")
+			}
+			if tt.expectReason != "" && output.Reason != tt.expectReason {
+				t.Errorf("reason = %q, want %q", output.Reason, tt.expectReason)
+			}
+			if output.HookSpecificOutput.AdditionalContext != tt.expectContext {
+				t.Errorf("additionalContext = %q, want %q", output.HookSpecificOutput.AdditionalContext, tt.expectContext)
+			}
+		})
+	}
+}
+
+func TestFramework_IsAuditOnly(t *testing.T) {
+	tests := []struct {
+		name     string
+		hookType string
+		rawData  map[string]any
+		expect   bool
+	}{
+		{name: "stop", hookType: stopType, rawData: map[string]any{"stop_hook_active": false}},
+		{name: "stop hook active", hookType: stopType, rawData: map[string]any{"stop_hook_active": true}, expect: true},
+		{name: "subagent stop hook active", hookType: subagentStopType, rawData: map[string]any{"stop_hook_active": true}, expect: true},
+		{name: "other hook", hookType: preToolUseType, rawData: map[string]any{"stop_hook_active": true}},
+	}
+
+	f := NewFramework()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := types.HookInput{Framework: frameworkName, HookType: tt.hookType, RawData: tt.rawData}
+			if got := f.IsAuditOnly(input); got != tt.expect {
+				t.Errorf("IsAuditOnly() = %v, want %v", got, tt.expect)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package claude
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const (
+	postToolUseType = "PostToolUse"
+
+	toolRead     = "Read"
+	toolWebFetch = "WebFetch"
+
+	// postToolUseGuidance is appended to the reason fed back to the model after a tool result contained secrets
+	postToolUseGuidance = "\n\nThe tool output above contains sensitive values. Do not repeat, quote, summarize, " +
+		"or reuse these values in responses, files, or subsequent tool calls."
+)
+
+// PostToolUseHandler handles the PostToolUse hook
+// It scans tool output before it re-enters the model context
+type PostToolUseHandler struct{}
+
+// Force compile-time check for interface implementation
+var _ framework.HookHandler = (*PostToolUseHandler)(nil)
+
+// NewPostToolUseHandler creates a new PostToolUse handler
+func NewPostToolUseHandler() *PostToolUseHandler {
+	return &PostToolUseHandler{}
+}
+
+// ExtractContent extracts the tool response for scanning
+func (h *PostToolUseHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
+	var toolOutput PostToolUseInput
+
+	if err := framework.DecodeRawData(input.RawData, &toolOutput); err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to unmarshal PostToolUse input; %w", err)
+	}
+
+	content := types.ScanContent{
+		Type: "text",
+		Metadata: map[string]string{
+			"session_id":      toolOutput.SessionID,
+			"transcript_path": toolOutput.TranscriptPath,
+			"cwd":             toolOutput.CWD,
+			"tool_name":       toolOutput.ToolName,
+		},
+	}
+
+	response, isStructured := toolOutput.ToolResponse.(map[string]any)
+
+	switch {
+	case toolOutput.ToolName == toolBash && isStructured:
+		content.Content = joinNonEmpty(stringField(response, "stdout"), stringField(response, "stderr"))
+	case toolOutput.ToolName == toolRead && isStructured:
+		file, _ := response["file"].(map[string]any)
+		content.Content = stringField(file, "content")
+		if filePath := stringField(file, "filePath"); filePath != "" {
+			content.Metadata["file_path"] = filePath
+		}
+	case toolOutput.ToolName == toolWebFetch && isStructured:
+		content.Content = stringField(response, "result")
+	default:
+		// Unknown or unstructured responses are scanned in full
+		content.Content = strings.Join(framework.CollectStrings(toolOutput.ToolResponse), "\n")
+	}
+
+	// Fall back to the requested path when the response does not carry one
+	if _, ok := content.Metadata["file_path"]; !ok {
+		if filePath, ok := toolOutput.ToolInput["file_path"].(string); ok && filePath != "" {
+			content.Metadata["file_path"] = filePath
+		}
+	}
+
+	return content, nil
+}
+
+// GetType returns the hook type name
+func (h *PostToolUseHandler) GetType() string {
+	return postToolUseType
+}
+
+// CanHandle returns true if this handler can process the given hook input
+func (h *PostToolUseHandler) CanHandle(input types.HookInput) bool {
+	return input.Framework == "claude" && input.HookType == postToolUseType
+}
+
+// stringField returns the string value stored under key, or an empty string
+func stringField(m map[string]any, key string) string {
+	value, _ := m[key].(string)
+	return value
+}
+
+// joinNonEmpty joins the non-empty values with newlines
+func joinNonEmpty(values ...string) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
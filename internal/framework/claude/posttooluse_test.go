@@ -0,0 +1,102 @@
+package claude
+
+import (
+	"context"
+	"testing"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+func TestPostToolUseHandler_ExtractContent(t *testing.T) {
+	tests := []struct {
+		name           string
+		rawData        map[string]any
+		expectContent  string
+		expectFilePath string
+	}{
+		{
+			name: "bash stdout and stderr",
+			rawData: map[string]any{
+				"tool_name": "Bash",
+				"tool_response": map[string]any{
+					"stdout":      "out",
+					"stderr":      "err",
+					"interrupted": false,
+				},
+			},
+			expectContent: "out\nerr",
+		},
+		{
+			name: "read file content",
+			rawData: map[string]any{
+				"tool_name":  "Read",
+				"tool_input": map[string]any{"file_path": "/project/.env"},
+				"tool_response": map[string]any{
+					"type": "text",
+					"file": map[string]any{
+						"filePath": "/project/.env",
+						"content":  "TOKEN=value",
+					},
+				},
+			},
+			expectContent:  "TOKEN=value",
+			expectFilePath: "/project/.env",
+		},
+		{
+			name: "webfetch result",
+			rawData: map[string]any{
+				"tool_name": "WebFetch",
+				"tool_response": map[string]any{
+					"code":   float64(200),
+					"result": "page body",
+					"url":    "https://example.com",
+				},
+			},
+			expectContent: "page body",
+		},
+		{
+			name: "unknown tool collects all strings",
+			rawData: map[string]any{
+				"tool_name": "mcp__server__tool",
+				"tool_response": map[string]any{
+					"b": []any{"second", map[string]any{"c": "third"}},
+					"a": "first",
+				},
+			},
+			expectContent: "first\nsecond\nthird",
+		},
+		{
+			name: "plain string response",
+			rawData: map[string]any{
+				"tool_name":     "Grep",
+				"tool_response": "match",
+			},
+			expectContent: "match",
+		},
+	}
+
+	handler := NewPostToolUseHandler()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rawData["hook_event_name"] = postToolUseType
+			input := types.HookInput{
+				Framework: frameworkName,
+				HookType:  postToolUseType,
+				RawData:   tt.rawData,
+			}
+
+			content, err := handler.ExtractContent(context.Background(), input)
+			if err != nil {
+				t.Fatalf("ExtractContent() failed: %v", err)
+			}
+
+			if content.Content != tt.expectContent {
+				t.Errorf("Content = %q, want %q", content.Content, tt.expectContent)
+			}
+			if content.Metadata["file_path"] != tt.expectFilePath {
+				t.Errorf("file_path = %q, want %q", content.Metadata["file_path"], tt.expectFilePath)
+			}
+		})
+	}
+}
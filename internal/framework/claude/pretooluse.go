@@ -0,0 +1,102 @@
+package claude
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const (
+	preToolUseType = "PreToolUse"
+
+	toolBash      = "Bash"
+	toolWrite     = "Write"
+	toolEdit      = "Edit"
+	toolMultiEdit = "MultiEdit"
+)
+
+// PreToolUseHandler handles the PreToolUse hook
+// Only tool calls whose input may carry secrets are scanned; other tools yield empty content
+type PreToolUseHandler struct{}
+
+// Force compile-time check for interface implementation
+var _ framework.HookHandler = (*PreToolUseHandler)(nil)
+
+// NewPreToolUseHandler creates a new PreToolUse handler
+func NewPreToolUseHandler() *PreToolUseHandler {
+	return &PreToolUseHandler{}
+}
+
+// ExtractContent extracts the tool input that is about to be executed for scanning
+func (h *PreToolUseHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
+	var toolInput PreToolUseInput
+
+	if err := framework.DecodeRawData(input.RawData, &toolInput); err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to unmarshal PreToolUse input; %w", err)
+	}
+
+	content := types.ScanContent{
+		Type: "text",
+		Metadata: map[string]string{
+			"session_id":      toolInput.SessionID,
+			"transcript_path": toolInput.TranscriptPath,
+			"cwd":             toolInput.CWD,
+			"tool_name":       toolInput.ToolName,
+		},
+	}
+
+	switch toolInput.ToolName {
+	case toolBash:
+		command, _ := toolInput.ToolInput["command"].(string)
+		content.Content = command
+	case toolWrite:
+		fileContent, _ := toolInput.ToolInput["content"].(string)
+		content.Content = fileContent
+	case toolEdit:
+		newString, _ := toolInput.ToolInput["new_string"].(string)
+		content.Content = newString
+	case toolMultiEdit:
+		content.Content = extractMultiEditContent(toolInput.ToolInput)
+	}
+
+	// Pass the target path through so findings report it and the scanner can preserve the file type
+	if filePath, ok := toolInput.ToolInput["file_path"].(string); ok && filePath != "" {
+		content.Metadata["file_path"] = filePath
+	}
+
+	return content, nil
+}
+
+// extractMultiEditContent joins the new_string of every edit in a MultiEdit tool call
+func extractMultiEditContent(toolInput map[string]any) string {
+	edits, ok := toolInput["edits"].([]any)
+	if !ok {
+		return ""
+	}
+
+	parts := make([]string, 0, len(edits))
+	for _, edit := range edits {
+		editMap, ok := edit.(map[string]any)
+		if !ok {
+			continue
+		}
+		if newString, ok := editMap["new_string"].(string); ok && newString != "" {
+			parts = append(parts, newString)
+		}
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// GetType returns the hook type name
+func (h *PreToolUseHandler) GetType() string {
+	return preToolUseType
+}
+
+// CanHandle returns true if this handler can process the given hook input
+func (h *PreToolUseHandler) CanHandle(input types.HookInput) bool {
+	return input.Framework == "claude" && input.HookType == preToolUseType
+}
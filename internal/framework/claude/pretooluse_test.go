@@ -0,0 +1,107 @@
+package claude
+
+import (
+	"context"
+	"testing"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+func TestPreToolUseHandler_ExtractContent(t *testing.T) {
+	tests := []struct {
+		name           string
+		rawData        map[string]any
+		expectContent  string
+		expectFilePath string
+	}{
+		{
+			name: "bash command",
+			rawData: map[string]any{
+				"tool_name": "Bash",
+				"tool_input": map[string]any{
+					"command":     "export TOKEN=value",
+					"description": "Set the token",
+				},
+			},
+			expectContent: "export TOKEN=value",
+		},
+		{
+			name: "write content",
+			rawData: map[string]any{
+				"tool_name": "Write",
+				"tool_input": map[string]any{
+					"file_path": "/project/.env",
+					"content":   "TOKEN=value",
+				},
+			},
+			expectContent:  "TOKEN=value",
+			expectFilePath: "/project/.env",
+		},
+		{
+			name: "edit new string",
+			rawData: map[string]any{
+				"tool_name": "Edit",
+				"tool_input": map[string]any{
+					"file_path":  "/project/config.go",
+					"old_string": "token := \"\"",
+					"new_string": "token := \"value\"",
+				},
+			},
+			expectContent:  "token := \"value\"",
+			expectFilePath: "/project/config.go",
+		},
+		{
+			name: "multiedit joins every new string",
+			rawData: map[string]any{
+				"tool_name": "MultiEdit",
+				"tool_input": map[string]any{
+					"file_path": "/project/config.yaml",
+					"edits": []any{
+						map[string]any{"old_string": "a", "new_string": "first"},
+						map[string]any{"old_string": "b", "new_string": ""},
+						"not an edit",
+						map[string]any{"old_string": "c", "new_string": "second"},
+					},
+				},
+			},
+			expectContent:  "first\nsecond",
+			expectFilePath: "/project/config.yaml",
+		},
+		{
+			name: "tool that is not inspected",
+			rawData: map[string]any{
+				"tool_name":  "Read",
+				"tool_input": map[string]any{"file_path": "/project/.env"},
+			},
+			expectFilePath: "/project/.env",
+		},
+	}
+
+	handler := NewPreToolUseHandler()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rawData["hook_event_name"] = preToolUseType
+			input := types.HookInput{
+				Framework: frameworkName,
+				HookType:  preToolUseType,
+				RawData:   tt.rawData,
+			}
+
+			content, err := handler.ExtractContent(context.Background(), input)
+			if err != nil {
+				t.Fatalf("ExtractContent() failed: %v", err)
+			}
+
+			if content.Content != tt.expectContent {
+				t.Errorf("Content = %q, want %q", content.Content, tt.expectContent)
+			}
+			if content.Metadata["file_path"] != tt.expectFilePath {
+				t.Errorf("file_path = %q, want %q", content.Metadata["file_path"], tt.expectFilePath)
+			}
+			if content.Metadata["tool_name"] != tt.rawData["tool_name"] {
+				t.Errorf("tool_name = %q, want %q", content.Metadata["tool_name"], tt.rawData["tool_name"])
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package claude
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const (
+	sessionStartType = "SessionStart"
+
+	// Limits that keep the pre-flight scan lightweight
+	sensitiveFileMaxDepth = 2       // Directory levels below cwd to search
+	sensitiveFileMaxCount = 20      // Maximum number of files to scan
+	sensitiveFileMaxSize  = 1 << 20 // Files larger than this (1 MiB) are skipped
+
+	// sessionStartPreamble is injected into the model context at the start of every session
+	sessionStartPreamble = "Secret scanning with HashiCorp Vault Radar is active for this session. " +
+		"Never print, echo, or copy credentials, tokens, or private keys into prompts, files, or commands; " +
+		"reference them through environment variables or a secrets manager instead."
+)
+
+// sensitiveFilePatterns are the file name patterns checked during the pre-flight scan
+var sensitiveFilePatterns = []string{
+	".env*",
+	"*.pem",
+	"credentials",
+	"terraform.tfvars",
+}
+
+// skippedDirectories are never descended into during the pre-flight scan
+var skippedDirectories = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// SessionStartHandler handles the SessionStart hook
+// It pre-flight scans well-known sensitive files in the working directory
+type SessionStartHandler struct{}
+
+// Force compile-time check for interface implementation
+var _ framework.HookHandler = (*SessionStartHandler)(nil)
+
+// NewSessionStartHandler creates a new SessionStart handler
+func NewSessionStartHandler() *SessionStartHandler {
+	return &SessionStartHandler{}
+}
+
+// ExtractContent collects well-known sensitive files under cwd, one scan part per file
+func (h *SessionStartHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
+	var sessionInput SessionStartInput
+
+	if err := framework.DecodeRawData(input.RawData, &sessionInput); err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to unmarshal SessionStart input; %w", err)
+	}
+
+	content := types.ScanContent{
+		Type: "file",
+		Metadata: map[string]string{
+			"session_id":      sessionInput.SessionID,
+			"transcript_path": sessionInput.TranscriptPath,
+			"cwd":             sessionInput.CWD,
+			"source":          sessionInput.Source,
+		},
+	}
+
+	if sessionInput.CWD == "" {
+		return content, nil
+	}
+
+	paths, err := findSensitiveFiles(sessionInput.CWD)
+	if err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to search for sensitive files; %w", err)
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil || len(data) == 0 {
+			continue
+		}
+
+		content.Parts = append(content.Parts, types.ScanContent{
+			Type:    "file",
+			Content: string(data),
+			Metadata: map[string]string{
+				"session_id": sessionInput.SessionID,
+				"cwd":        sessionInput.CWD,
+				"file_path":  path,
+			},
+		})
+	}
+
+	return content, nil
+}
+
+// GetType returns the hook type name
+func (h *SessionStartHandler) GetType() string {
+	return sessionStartType
+}
+
+// CanHandle returns true if this handler can process the given hook input
+func (h *SessionStartHandler) CanHandle(input types.HookInput) bool {
+	return input.Framework == "claude" && input.HookType == sessionStartType
+}
+
+// findSensitiveFiles returns regular files under root matching sensitiveFilePatterns
+// The search is bounded by depth, file count and file size
+func findSensitiveFiles(root string) ([]string, error) {
+	var paths []string
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			// Unreadable entries are skipped rather than failing the session
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			if skippedDirectories[d.Name()] || depth(root, path) > sensitiveFileMaxDepth {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if !d.Type().IsRegular() || !matchesSensitivePattern(d.Name()) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil || info.Size() > sensitiveFileMaxSize {
+			return nil
+		}
+
+		paths = append(paths, path)
+		if len(paths) >= sensitiveFileMaxCount {
+			return fs.SkipAll
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+// matchesSensitivePattern reports whether a file name matches any sensitive file pattern
+func matchesSensitivePattern(name string) bool {
+	for _, pattern := range sensitiveFilePatterns {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// depth returns how many directory levels path is below root
+func depth(root, path string) int {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return 0
+	}
+	return strings.Count(rel, string(filepath.Separator)) + 1
+}
+
+// buildSessionContext creates the additionalContext for SessionStart, listing files that contain secrets
+func buildSessionContext(decision types.Decision) string {
+	var sb strings.Builder
+	sb.WriteString(sessionStartPreamble)
+
+	findings, _ := decision.Metadata["findings"].([]types.Finding)
+	if !requiresIntervention(decision) || len(findings) == 0 {
+		return sb.String()
+	}
+
+	// Group finding types by file
+	typesByFile := make(map[string][]string)
+	for _, finding := range findings {
+		if finding.Location == "" {
+			continue
+		}
+		typesByFile[finding.Location] = append(typesByFile[finding.Location], finding.Type)
+	}
+
+	files := make([]string, 0, len(typesByFile))
+	for file := range typesByFile {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	sb.WriteString("\n\nThe following files in the working directory contain secrets. ")
+	sb.WriteString("Do not read, print, echo, or copy their contents:")
+	for _, file := range files {
+		sb.WriteString("\n- ")
+		sb.WriteString(file)
+		sb.WriteString(" (")
+		sb.WriteString(strings.Join(typesByFile[file], ", "))
+		sb.WriteString(")")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,80 @@
+package claude
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+func TestFindSensitiveFiles(t *testing.T) {
+	root := filepath.Join("..", "..", "..", "testdata", "claude", "project")
+
+	paths, err := findSensitiveFiles(root)
+	if err != nil {
+		t.Fatalf("findSensitiveFiles() failed: %v", err)
+	}
+
+	expected := []string{
+		filepath.Join(root, ".env"),
+		filepath.Join(root, ".env.example"),
+		filepath.Join(root, "config", "terraform.tfvars"),
+	}
+
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("findSensitiveFiles() = %v, want %v", paths, expected)
+	}
+}
+
+func TestBuildSessionContext(t *testing.T) {
+	tests := []struct {
+		name           string
+		decision       types.Decision
+		expectContains []string
+		expectMissing  []string
+	}{
+		{
+			name:           "clean session gets preamble only",
+			decision:       types.Decision{Metadata: map[string]any{}},
+			expectContains: []string{"Secret scanning with HashiCorp Vault Radar is active"},
+			expectMissing:  []string{"contain secrets"},
+		},
+		{
+			name: "files with secrets are listed",
+			decision: types.Decision{
+				Block: true,
+				Metadata: map[string]any{
+					"findings": []types.Finding{
+						{Type: "aws_access_key_id", Location: "/project/.env"},
+						{Type: "github_personal_access_token", Location: "/project/config/terraform.tfvars"},
+						{Type: "aws_secret_access_key", Location: "/project/.env"},
+					},
+				},
+			},
+			expectContains: []string{
+				"Secret scanning with HashiCorp Vault Radar is active",
+				"- /project/.env (aws_access_key_id, aws_secret_access_key)",
+				"- /project/config/terraform.tfvars (github_personal_access_token)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			context := buildSessionContext(tt.decision)
+
+			for _, expected := range tt.expectContains {
+				if !strings.Contains(context, expected) {
+					t.Errorf("expected context to contain %q, got: %s", expected, context)
+				}
+			}
+			for _, missing := range tt.expectMissing {
+				if strings.Contains(context, missing) {
+					t.Errorf("expected context not to contain %q, got: %s", missing, context)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package claude
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const (
+	stopType         = "Stop"
+	subagentStopType = "SubagentStop"
+
+	// stopGuidance is appended to the reason when stopping is blocked because of secrets in the transcript
+	stopGuidance = "\n\nSecrets appeared in recent conversation turns. Do not repeat these values; " +
+		"tell the user which credentials were exposed so they can be rotated, then stop."
+)
+
+// StopHandler handles the Stop and SubagentStop hooks
+// It sweeps the session transcript, scanning only the turns added since the previous sweep
+type StopHandler struct {
+	hookType string
+	sweeper  *transcriptSweeper
+}
+
+// Force compile-time check for interface implementation
+var (
+	_ framework.HookHandler      = (*StopHandler)(nil)
+	_ framework.ContentCommitter = (*StopHandler)(nil)
+)
+
+// NewStopHandler creates a new Stop handler that persists sweep offsets in stateDir
+func NewStopHandler(stateDir string) *StopHandler {
+	return &StopHandler{
+		hookType: stopType,
+		sweeper:  newTranscriptSweeper(stateDir),
+	}
+}
+
+// NewSubagentStopHandler creates a new SubagentStop handler that persists sweep offsets in stateDir
+func NewSubagentStopHandler(stateDir string) *StopHandler {
+	return &StopHandler{
+		hookType: subagentStopType,
+		sweeper:  newTranscriptSweeper(stateDir),
+	}
+}
+
+// ExtractContent extracts the transcript turns added since the last sweep for scanning
+func (h *StopHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
+	var stopInput StopInput
+
+	if err := framework.DecodeRawData(input.RawData, &stopInput); err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to unmarshal %s input; %w", h.hookType, err)
+	}
+
+	content := types.ScanContent{
+		Type: "text",
+		Metadata: map[string]string{
+			"session_id":      stopInput.SessionID,
+			"transcript_path": stopInput.TranscriptPath,
+			"cwd":             stopInput.CWD,
+		},
+	}
+
+	if stopInput.TranscriptPath == "" {
+		return content, nil
+	}
+
+	sweep, err := h.sweeper.Sweep(stopInput.SessionID, stopInput.TranscriptPath)
+	if err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to sweep transcript; %w", err)
+	}
+
+	content.Content = sweep.Content
+	content.Metadata["file_path"] = stopInput.TranscriptPath
+	content.Metadata["location"] = fmt.Sprintf("%s, lines %d-%d", stopInput.TranscriptPath, sweep.FirstLine, sweep.LastLine)
+	content.Metadata["transcript_offset"] = strconv.FormatInt(sweep.Position.Offset, 10)
+	content.Metadata["transcript_line"] = strconv.Itoa(sweep.Position.Line)
+
+	return content, nil
+}
+
+// CommitContent saves the transcript position reached by ExtractContent once the swept turns were scanned
+func (h *StopHandler) CommitContent(input types.HookInput, content types.ScanContent) error {
+	offset, ok := content.Metadata["transcript_offset"]
+	if !ok {
+		return nil
+	}
+
+	var position transcriptPosition
+	var err error
+	if position.Offset, err = strconv.ParseInt(offset, 10, 64); err != nil {
+		return fmt.Errorf("invalid transcript offset %q; %w", offset, err)
+	}
+	if position.Line, err = strconv.Atoi(content.Metadata["transcript_line"]); err != nil {
+		return fmt.Errorf("invalid transcript line %q; %w", content.Metadata["transcript_line"], err)
+	}
+
+	if err := h.sweeper.Commit(content.Metadata["session_id"], content.Metadata["transcript_path"], position); err != nil {
+		return fmt.Errorf("failed to save transcript position; %w", err)
+	}
+
+	return nil
+}
+
+// GetType returns the hook type name
+func (h *StopHandler) GetType() string {
+	return h.hookType
+}
+
+// CanHandle returns true if this handler can process the given hook input
+func (h *StopHandler) CanHandle(input types.HookInput) bool {
+	return input.Framework == "claude" && input.HookType == h.hookType
+}
@@ -0,0 +1,235 @@
+package claude
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/filelock"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+)
+
+// unsafeSessionChars matches characters that are not allowed in session state file names
+var unsafeSessionChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// transcriptPosition records how far a transcript has been swept
+type transcriptPosition struct {
+	Offset int64 `json:"offset"` // Byte offset just past the last swept line
+	Line   int   `json:"line"`   // Number of lines swept so far
+}
+
+// transcriptState is the persisted sweep state for a single session
+type transcriptState struct {
+	Transcripts map[string]transcriptPosition `json:"transcripts"` // Keyed by transcript path
+	UpdatedAt   time.Time                     `json:"updated_at"`
+}
+
+// transcriptSweep holds the transcript text added since the previous sweep
+type transcriptSweep struct {
+	Content   string             // Text of the new transcript entries, one entry per line
+	FirstLine int                // 1-based transcript line of the first new entry
+	LastLine  int                // 1-based transcript line of the last new entry
+	Position  transcriptPosition // Position after the new entries; see Commit
+}
+
+// transcriptSweeper reads JSONL transcripts incrementally, persisting offsets per session
+type transcriptSweeper struct {
+	stateDir string
+}
+
+// newTranscriptSweeper creates a sweeper that stores its state in stateDir
+func newTranscriptSweeper(stateDir string) *transcriptSweeper {
+	return &transcriptSweeper{
+		stateDir: stateDir,
+	}
+}
+
+// Sweep returns the transcript entries added since the last committed sweep for the session
+// The offset is not advanced until the sweep is committed, so entries that fail to scan are swept again
+func (s *transcriptSweeper) Sweep(sessionID, transcriptPath string) (transcriptSweep, error) {
+	statePath, err := s.statePath(sessionID)
+	if err != nil {
+		return transcriptSweep{}, err
+	}
+
+	state, err := s.loadState(statePath)
+	if err != nil {
+		return transcriptSweep{}, err
+	}
+
+	file, err := os.Open(transcriptPath)
+	if err != nil {
+		return transcriptSweep{}, fmt.Errorf("failed to open transcript; %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return transcriptSweep{}, fmt.Errorf("failed to stat transcript; %w", err)
+	}
+
+	// Start over if the transcript was truncated or replaced since the last sweep
+	position := state.Transcripts[transcriptPath]
+	if position.Offset > info.Size() {
+		position = transcriptPosition{}
+	}
+
+	if _, err := file.Seek(position.Offset, io.SeekStart); err != nil {
+		return transcriptSweep{}, fmt.Errorf("failed to seek transcript; %w", err)
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return transcriptSweep{}, fmt.Errorf("failed to read transcript; %w", err)
+	}
+
+	// Only consume complete lines; a partially written entry is picked up by the next sweep
+	end := bytes.LastIndexByte(data, '\n')
+	if end < 0 {
+		return transcriptSweep{Position: position}, nil
+	}
+	data = data[:end+1]
+
+	sweep := transcriptSweep{
+		FirstLine: position.Line + 1,
+	}
+
+	var entries []string
+	for _, line := range bytes.Split(data[:end], []byte("\n")) {
+		position.Line++
+
+		if text := transcriptEntryText(line); text != "" {
+			entries = append(entries, text)
+		}
+	}
+
+	sweep.Content = strings.Join(entries, "\n")
+	sweep.LastLine = position.Line
+	position.Offset += int64(len(data))
+	sweep.Position = position
+
+	return sweep, nil
+}
+
+// Commit records the position reached by a sweep of the session's transcript
+// The state file is updated under a file lock, since Stop and SubagentStop hooks of a session can run concurrently
+func (s *transcriptSweeper) Commit(sessionID, transcriptPath string, position transcriptPosition) error {
+	statePath, err := s.statePath(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.stateDir, 0700); err != nil {
+		return fmt.Errorf("failed to create transcript state directory; %w", err)
+	}
+
+	unlock, err := filelock.Lock(statePath + ".lock")
+	if err != nil {
+		return fmt.Errorf("failed to lock transcript state; %w", err)
+	}
+	defer unlock()
+
+	state, err := s.loadState(statePath)
+	if err != nil {
+		return err
+	}
+
+	state.Transcripts[transcriptPath] = position
+	return s.saveState(statePath, state)
+}
+
+// statePath returns the state file path for a session
+func (s *transcriptSweeper) statePath(sessionID string) (string, error) {
+	name := unsafeSessionChars.ReplaceAllString(sessionID, "_")
+	if strings.Trim(name, "._") == "" {
+		return "", fmt.Errorf("invalid session_id %q", sessionID)
+	}
+
+	return filepath.Join(s.stateDir, name+".json"), nil
+}
+
+// loadState reads the session state, returning empty state if none has been persisted yet
+func (s *transcriptSweeper) loadState(path string) (transcriptState, error) {
+	state := transcriptState{
+		Transcripts: make(map[string]transcriptPosition),
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read transcript state; %w", err)
+	}
+
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("failed to parse transcript state; %w", err)
+	}
+
+	if state.Transcripts == nil {
+		state.Transcripts = make(map[string]transcriptPosition)
+	}
+
+	return state, nil
+}
+
+// saveState atomically writes the session state through a uniquely named temporary file
+func (s *transcriptSweeper) saveState(path string, state transcriptState) error {
+	state.UpdatedAt = time.Now().UTC()
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transcript state; %w", err)
+	}
+
+	file, err := os.CreateTemp(s.stateDir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write transcript state; %w", err)
+	}
+	_, writeErr := file.Write(data)
+	closeErr := file.Close()
+	if writeErr != nil || closeErr != nil {
+		os.Remove(file.Name())
+		return fmt.Errorf("failed to write transcript state; %w", errors.Join(writeErr, closeErr))
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		os.Remove(file.Name())
+		return fmt.Errorf("failed to save transcript state; %w", err)
+	}
+
+	return nil
+}
+
+// transcriptEntryText returns the scannable text of a single transcript line
+// Only the message body is scanned; bookkeeping fields such as uuids and timestamps are skipped
+func transcriptEntryText(line []byte) string {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return ""
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(line, &entry); err != nil {
+		// Not JSON; scan the raw line rather than silently skipping it
+		return string(line)
+	}
+
+	message, ok := entry["message"]
+	if !ok {
+		return ""
+	}
+
+	if messageMap, ok := message.(map[string]any); ok {
+		message = messageMap["content"]
+	}
+
+	return strings.Join(framework.CollectStrings(message), "\n")
+}
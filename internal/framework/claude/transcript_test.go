@@ -0,0 +1,195 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTranscriptSweeper_Incremental(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+	sweeper := newTranscriptSweeper(filepath.Join(tmpDir, "state"))
+
+	writeTranscript(t, transcriptPath,
+		`{"type":"user","uuid":"u1","message":{"role":"user","content":"first prompt"}}`+"\n"+
+			`{"type":"assistant","uuid":"a1","message":{"role":"assistant","content":[{"type":"text","text":"first answer"}]}}`+"\n")
+
+	sweep, err := sweeper.Sweep("session-1", transcriptPath)
+	if err != nil {
+		t.Fatalf("first Sweep() failed: %v", err)
+	}
+	commitSweep(t, sweeper, "session-1", transcriptPath, sweep)
+	if !strings.Contains(sweep.Content, "first prompt") || !strings.Contains(sweep.Content, "first answer") {
+		t.Errorf("first sweep missing entries: %q", sweep.Content)
+	}
+	if strings.Contains(sweep.Content, "u1") {
+		t.Errorf("first sweep should not include bookkeeping fields: %q", sweep.Content)
+	}
+	if sweep.FirstLine != 1 || sweep.LastLine != 2 {
+		t.Errorf("first sweep lines = %d-%d, want 1-2", sweep.FirstLine, sweep.LastLine)
+	}
+
+	// Append a complete entry and a partially written one
+	appendTranscript(t, transcriptPath,
+		`{"type":"user","uuid":"u2","message":{"role":"user","content":"second prompt"}}`+"\n"+
+			`{"type":"assistant","message":`)
+
+	sweep, err = sweeper.Sweep("session-1", transcriptPath)
+	if err != nil {
+		t.Fatalf("second Sweep() failed: %v", err)
+	}
+	commitSweep(t, sweeper, "session-1", transcriptPath, sweep)
+	if sweep.Content != "second prompt" {
+		t.Errorf("second sweep content = %q, want %q", sweep.Content, "second prompt")
+	}
+	if sweep.FirstLine != 3 || sweep.LastLine != 3 {
+		t.Errorf("second sweep lines = %d-%d, want 3-3", sweep.FirstLine, sweep.LastLine)
+	}
+
+	// Complete the partial entry
+	appendTranscript(t, transcriptPath, `{"role":"assistant","content":"second answer"}}`+"\n")
+
+	sweep, err = sweeper.Sweep("session-1", transcriptPath)
+	if err != nil {
+		t.Fatalf("third Sweep() failed: %v", err)
+	}
+	commitSweep(t, sweeper, "session-1", transcriptPath, sweep)
+	if sweep.Content != "second answer" {
+		t.Errorf("third sweep content = %q, want %q", sweep.Content, "second answer")
+	}
+
+	// Nothing new
+	sweep, err = sweeper.Sweep("session-1", transcriptPath)
+	if err != nil {
+		t.Fatalf("fourth Sweep() failed: %v", err)
+	}
+	commitSweep(t, sweeper, "session-1", transcriptPath, sweep)
+	if sweep.Content != "" {
+		t.Errorf("fourth sweep content = %q, want empty", sweep.Content)
+	}
+
+	// A different session starts from the beginning
+	sweep, err = sweeper.Sweep("session-2", transcriptPath)
+	if err != nil {
+		t.Fatalf("other session Sweep() failed: %v", err)
+	}
+	commitSweep(t, sweeper, "session-2", transcriptPath, sweep)
+	if !strings.Contains(sweep.Content, "first prompt") {
+		t.Errorf("other session should sweep the whole transcript: %q", sweep.Content)
+	}
+}
+
+func TestTranscriptSweeper_Truncated(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+	sweeper := newTranscriptSweeper(filepath.Join(tmpDir, "state"))
+
+	writeTranscript(t, transcriptPath,
+		`{"type":"user","message":{"role":"user","content":"a fairly long original prompt"}}`+"\n")
+	sweep, err := sweeper.Sweep("session-1", transcriptPath)
+	if err != nil {
+		t.Fatalf("Sweep() failed: %v", err)
+	}
+	commitSweep(t, sweeper, "session-1", transcriptPath, sweep)
+
+	writeTranscript(t, transcriptPath, `{"type":"user","message":{"role":"user","content":"new"}}`+"\n")
+
+	sweep, err = sweeper.Sweep("session-1", transcriptPath)
+	if err != nil {
+		t.Fatalf("Sweep() after truncation failed: %v", err)
+	}
+	commitSweep(t, sweeper, "session-1", transcriptPath, sweep)
+	if sweep.Content != "new" {
+		t.Errorf("content after truncation = %q, want %q", sweep.Content, "new")
+	}
+}
+
+func TestTranscriptSweeper_Uncommitted(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+	sweeper := newTranscriptSweeper(filepath.Join(tmpDir, "state"))
+
+	writeTranscript(t, transcriptPath, `{"type":"user","message":{"role":"user","content":"first prompt"}}`+"\n")
+
+	// A sweep whose scan failed is not committed, so the next sweep returns the same entries
+	if _, err := sweeper.Sweep("session-1", transcriptPath); err != nil {
+		t.Fatalf("Sweep() failed: %v", err)
+	}
+
+	appendTranscript(t, transcriptPath, `{"type":"user","message":{"role":"user","content":"second prompt"}}`+"\n")
+
+	sweep, err := sweeper.Sweep("session-1", transcriptPath)
+	if err != nil {
+		t.Fatalf("Sweep() failed: %v", err)
+	}
+	if sweep.Content != "first prompt\nsecond prompt" || sweep.FirstLine != 1 || sweep.LastLine != 2 {
+		t.Errorf("sweep after uncommitted sweep = %q, lines %d-%d, want both prompts on lines 1-2", sweep.Content, sweep.FirstLine, sweep.LastLine)
+	}
+}
+
+func TestTranscriptSweeper_ConcurrentCommits(t *testing.T) {
+	tmpDir := t.TempDir()
+	sweeper := newTranscriptSweeper(filepath.Join(tmpDir, "state"))
+
+	// Stop and SubagentStop hooks of one session commit positions of different transcripts to the same state file
+	var wg sync.WaitGroup
+	for i := range 64 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			path := filepath.Join(tmpDir, fmt.Sprintf("agent-%d.jsonl", i))
+			if err := sweeper.Commit("session-1", path, transcriptPosition{Offset: int64(i + 1), Line: 1}); err != nil {
+				t.Errorf("Commit() failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	statePath, _ := sweeper.statePath("session-1")
+	state, err := sweeper.loadState(statePath)
+	if err != nil {
+		t.Fatalf("loadState() failed: %v", err)
+	}
+	if len(state.Transcripts) != 64 {
+		t.Errorf("state has %d transcripts, want all 64 commits", len(state.Transcripts))
+	}
+}
+
+func TestTranscriptSweeper_InvalidSessionID(t *testing.T) {
+	sweeper := newTranscriptSweeper(t.TempDir())
+
+	if _, err := sweeper.Sweep("..", "/nonexistent"); err == nil {
+		t.Error("expected error for invalid session_id")
+	}
+}
+
+func commitSweep(t *testing.T, sweeper *transcriptSweeper, sessionID, path string, sweep transcriptSweep) {
+	t.Helper()
+	if err := sweeper.Commit(sessionID, path, sweep.Position); err != nil {
+		t.Fatalf("Commit() failed: %v", err)
+	}
+}
+
+func writeTranscript(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatalf("failed to write transcript: %v", err)
+	}
+}
+
+func appendTranscript(t *testing.T, path, data string) {
+	t.Helper()
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatalf("failed to open transcript: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(data); err != nil {
+		t.Fatalf("failed to append transcript: %v", err)
+	}
+}
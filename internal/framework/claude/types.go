@@ -9,6 +9,45 @@ type UserPromptSubmitInput struct {
 	Prompt         string `json:"prompt"`
 }
 
+// PreToolUseInput represents the input structure for PreToolUse hook
+type PreToolUseInput struct {
+	SessionID      string         `json:"session_id"`
+	TranscriptPath string         `json:"transcript_path"`
+	CWD            string         `json:"cwd"`
+	HookEventName  string         `json:"hook_event_name"`
+	ToolName       string         `json:"tool_name"`
+	ToolInput      map[string]any `json:"tool_input"`
+}
+
+// PostToolUseInput represents the input structure for PostToolUse hook
+type PostToolUseInput struct {
+	SessionID      string         `json:"session_id"`
+	TranscriptPath string         `json:"transcript_path"`
+	CWD            string         `json:"cwd"`
+	HookEventName  string         `json:"hook_event_name"`
+	ToolName       string         `json:"tool_name"`
+	ToolInput      map[string]any `json:"tool_input"`
+	ToolResponse   any            `json:"tool_response"`
+}
+
+// StopInput represents the input structure for Stop and SubagentStop hooks
+type StopInput struct {
+	SessionID      string `json:"session_id"`
+	TranscriptPath string `json:"transcript_path"`
+	CWD            string `json:"cwd"`
+	HookEventName  string `json:"hook_event_name"`
+	StopHookActive bool   `json:"stop_hook_active"`
+}
+
+// SessionStartInput represents the input structure for SessionStart hook
+type SessionStartInput struct {
+	SessionID      string `json:"session_id"`
+	TranscriptPath string `json:"transcript_path"`
+	CWD            string `json:"cwd"`
+	HookEventName  string `json:"hook_event_name"`
+	Source         string `json:"source"` // "startup", "resume", "clear" or "compact"
+}
+
 // HookOutput represents the common output structure for Claude hooks
 type HookOutput struct {
 	Decision           string             `json:"decision,omitempty"`
@@ -22,6 +61,8 @@ type HookOutput struct {
 
 // HookSpecificOutput contains hook-specific output fields
 type HookSpecificOutput struct {
-	HookEventName     string `json:"hookEventName,omitempty"`
-	AdditionalContext string `json:"additionalContext,omitempty"`
+	HookEventName            string `json:"hookEventName,omitempty"`
+	AdditionalContext        string `json:"additionalContext,omitempty"`
+	PermissionDecision       string `json:"permissionDecision,omitempty"`       // PreToolUse only: "allow", "deny" or "ask"
+	PermissionDecisionReason string `json:"permissionDecisionReason,omitempty"` // PreToolUse only
 }
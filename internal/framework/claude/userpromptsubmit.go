@@ -2,14 +2,19 @@ package claude
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
 	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
 )
 
-const userPromptSubmitType = "UserPromptSubmit"
+const (
+	userPromptSubmitType = "UserPromptSubmit"
+
+	// redactedPromptNotice introduces the redacted prompt offered for resubmission as additional context
+	redactedPromptNotice = "A redacted copy of the prompt with the secrets removed is below. " +
+		"It can be resubmitted as is:\n\n"
+)
 
 // UserPromptSubmitHandler handles the UserPromptSubmit hook
 type UserPromptSubmitHandler struct{}
@@ -26,13 +31,7 @@ func NewUserPromptSubmitHandler() *UserPromptSubmitHandler {
 func (h *UserPromptSubmitHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
 	var promptInput UserPromptSubmitInput
 
-	// Marshal and unmarshal to convert map to struct
-	data, err := json.Marshal(input.RawData)
-	if err != nil {
-		return types.ScanContent{}, fmt.Errorf("failed to marshal input data; %w", err)
-	}
-
-	if err := json.Unmarshal(data, &promptInput); err != nil {
+	if err := framework.DecodeRawData(input.RawData, &promptInput); err != nil {
 		return types.ScanContent{}, fmt.Errorf("failed to unmarshal UserPromptSubmit input; %w", err)
 	}
 
@@ -0,0 +1,72 @@
+package codex
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const agentTurnCompleteType = "agent-turn-complete"
+
+// AgentTurnCompleteHandler handles the agent-turn-complete notify event
+// Each input message and the last assistant message are scanned separately so findings name the message
+type AgentTurnCompleteHandler struct{}
+
+// Force compile-time check for interface implementation
+var _ framework.HookHandler = (*AgentTurnCompleteHandler)(nil)
+
+// NewAgentTurnCompleteHandler creates a new agent-turn-complete handler
+func NewAgentTurnCompleteHandler() *AgentTurnCompleteHandler {
+	return &AgentTurnCompleteHandler{}
+}
+
+// ExtractContent extracts the turn's input messages and last assistant message for scanning
+func (h *AgentTurnCompleteHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
+	var turnInput AgentTurnCompleteInput
+
+	if err := framework.DecodeRawData(input.RawData, &turnInput); err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to unmarshal agent-turn-complete input; %w", err)
+	}
+
+	content := types.ScanContent{
+		Type: "text",
+		Metadata: map[string]string{
+			"thread_id": turnInput.ThreadID,
+			"turn_id":   turnInput.TurnID,
+			"cwd":       turnInput.CWD,
+		},
+	}
+
+	for i, message := range turnInput.InputMessages {
+		if message == "" {
+			continue
+		}
+		content.Parts = append(content.Parts, types.ScanContent{
+			Type:     "text",
+			Content:  message,
+			Metadata: map[string]string{"location": fmt.Sprintf("input-messages[%d]", i)},
+		})
+	}
+
+	if turnInput.LastAssistantMessage != "" {
+		content.Parts = append(content.Parts, types.ScanContent{
+			Type:     "text",
+			Content:  turnInput.LastAssistantMessage,
+			Metadata: map[string]string{"location": "last-assistant-message"},
+		})
+	}
+
+	return content, nil
+}
+
+// GetType returns the hook type name
+func (h *AgentTurnCompleteHandler) GetType() string {
+	return agentTurnCompleteType
+}
+
+// CanHandle returns true if this handler can process the given hook input
+func (h *AgentTurnCompleteHandler) CanHandle(input types.HookInput) bool {
+	return input.Framework == frameworkName && input.HookType == agentTurnCompleteType
+}
@@ -0,0 +1,117 @@
+package codex
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const frameworkName = "codex"
+
+// Framework implements the HookFramework interface for OpenAI Codex CLI notify events
+// Codex runs notify programs after the fact, so decisions are audited rather than enforced
+type Framework struct {
+	handlers []framework.HookHandler
+}
+
+// Force compile-time check for interface implementation
+var (
+	_ framework.HookFramework      = (*Framework)(nil)
+	_ framework.AuditOnlyFramework = (*Framework)(nil)
+	_ framework.Detector           = (*Framework)(nil)
+)
+
+// NewFramework creates a new Codex framework instance
+func NewFramework() *Framework {
+	f := &Framework{
+		handlers: []framework.HookHandler{},
+	}
+
+	// Register default handlers
+	f.RegisterHandler(NewAgentTurnCompleteHandler())
+
+	return f
+}
+
+// RegisterHandler registers a hook handler with the framework
+func (f *Framework) RegisterHandler(handler framework.HookHandler) {
+	f.handlers = append(f.handlers, handler)
+}
+
+// GetHandler returns the appropriate handler for the given input
+func (f *Framework) GetHandler(input types.HookInput) (framework.HookHandler, error) {
+	for _, handler := range f.handlers {
+		if handler.CanHandle(input) {
+			return handler, nil
+		}
+	}
+	return nil, fmt.Errorf("no handler found for hook type %q", input.HookType)
+}
+
+// ParseInput reads and parses Codex notify data
+func (f *Framework) ParseInput(reader io.Reader) (types.HookInput, error) {
+	var rawData map[string]any
+
+	decoder := json.NewDecoder(reader)
+	if err := decoder.Decode(&rawData); err != nil {
+		return types.HookInput{}, fmt.Errorf("failed to decode JSON input; %w", err)
+	}
+
+	// Codex identifies the event with "type" rather than a hook event name
+	eventType, ok := rawData["type"].(string)
+	if !ok {
+		return types.HookInput{}, fmt.Errorf("missing or invalid type")
+	}
+
+	return types.HookInput{
+		Framework: frameworkName,
+		HookType:  eventType,
+		RawData:   rawData,
+	}, nil
+}
+
+// Detect returns true for payloads with a Codex notify event type
+func (f *Framework) Detect(rawData map[string]any) bool {
+	eventType, ok := rawData["type"].(string)
+	if !ok {
+		return false
+	}
+
+	_, err := f.GetHandler(types.HookInput{Framework: frameworkName, HookType: eventType})
+	return err == nil
+}
+
+// FormatOutput formats a decision as JSON for Codex
+func (f *Framework) FormatOutput(decision types.Decision, input types.HookInput) ([]byte, error) {
+	output := NotifyOutput{
+		AuditOnly: true,
+		Action:    decision.Action,
+		Reason:    decision.Reason,
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal output; %w", err)
+	}
+
+	return data, nil
+}
+
+// IsAuditOnly reports that Codex notify events cannot block
+func (f *Framework) IsAuditOnly(input types.HookInput) bool {
+	return true
+}
+
+// GetExitCode returns the appropriate exit code for Codex
+// Codex ignores the notify program's exit code, so it is always 0
+func (f *Framework) GetExitCode(decision types.Decision) int {
+	return 0
+}
+
+// GetName returns the framework name
+func (f *Framework) GetName() string {
+	return frameworkName
+}
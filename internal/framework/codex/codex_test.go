@@ -0,0 +1,104 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+func TestFramework_ExtractContent(t *testing.T) {
+	file, err := os.Open(filepath.Join("..", "..", "..", "testdata", "codex", "agent_turn_complete_clean.json"))
+	if err != nil {
+		t.Fatalf("failed to open fixture: %v", err)
+	}
+	defer file.Close()
+
+	f := NewFramework()
+
+	input, err := f.ParseInput(file)
+	if err != nil {
+		t.Fatalf("ParseInput() failed: %v", err)
+	}
+	if input.HookType != agentTurnCompleteType {
+		t.Errorf("HookType = %q, want %q", input.HookType, agentTurnCompleteType)
+	}
+
+	handler, err := f.GetHandler(input)
+	if err != nil {
+		t.Fatalf("GetHandler() failed: %v", err)
+	}
+
+	content, err := handler.ExtractContent(context.Background(), input)
+	if err != nil {
+		t.Fatalf("ExtractContent() failed: %v", err)
+	}
+
+	expected := []struct {
+		content  string
+		location string
+	}{
+		{"Rename the helper to parseConfig.", "input-messages[0]"},
+		{"Also update the tests.", "input-messages[1]"},
+		{"Renamed the helper to parseConfig and updated the tests.", "last-assistant-message"},
+	}
+
+	if len(content.Parts) != len(expected) {
+		t.Fatalf("len(Parts) = %d, want %d", len(content.Parts), len(expected))
+	}
+	for i, want := range expected {
+		part := content.Parts[i]
+		if part.Content != want.content {
+			t.Errorf("Parts[%d].Content = %q, want %q", i, part.Content, want.content)
+		}
+		if part.Metadata["location"] != want.location {
+			t.Errorf("Parts[%d] location = %q, want %q", i, part.Metadata["location"], want.location)
+		}
+	}
+	if content.Metadata["thread_id"] != "test-thread-123" {
+		t.Errorf("thread_id = %q, want %q", content.Metadata["thread_id"], "test-thread-123")
+	}
+}
+
+func TestFramework_ParseInput_MissingType(t *testing.T) {
+	f := NewFramework()
+
+	_, err := f.ParseInput(strings.NewReader(`{"turn-id": "test-turn-456"}`))
+	if err == nil {
+		t.Error("expected error for payload without type")
+	}
+}
+
+func TestFramework_FormatOutput(t *testing.T) {
+	f := NewFramework()
+
+	input := types.HookInput{Framework: frameworkName, HookType: agentTurnCompleteType}
+	decision := types.Decision{Block: true, Action: types.ActionDeny, Reason: "found"}
+
+	data, err := f.FormatOutput(decision, input)
+	if err != nil {
+		t.Fatalf("FormatOutput() failed: %v", err)
+	}
+
+	var output NotifyOutput
+	if err := json.Unmarshal(data, &output); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+
+	if !output.AuditOnly {
+		t.Error("expected audit_only to be true")
+	}
+	if output.Action != types.ActionDeny {
+		t.Errorf("action = %q, want %q", output.Action, types.ActionDeny)
+	}
+	if !f.IsAuditOnly(input) {
+		t.Error("expected IsAuditOnly() to be true")
+	}
+	if exitCode := f.GetExitCode(decision); exitCode != 0 {
+		t.Errorf("GetExitCode() = %d, want 0", exitCode)
+	}
+}
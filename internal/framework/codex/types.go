@@ -0,0 +1,19 @@
+package codex
+
+// AgentTurnCompleteInput represents the notify payload Codex sends after each agent turn
+type AgentTurnCompleteInput struct {
+	Type                 string   `json:"type"`
+	ThreadID             string   `json:"thread-id"`
+	TurnID               string   `json:"turn-id"`
+	CWD                  string   `json:"cwd"`
+	InputMessages        []string `json:"input-messages"`
+	LastAssistantMessage string   `json:"last-assistant-message"`
+}
+
+// NotifyOutput represents the output written after an audit
+// Codex ignores notify program output; it is emitted for logging and manual runs
+type NotifyOutput struct {
+	AuditOnly bool   `json:"audit_only"`
+	Action    string `json:"action"`           // Action that would have been taken if Codex could enforce it
+	Reason    string `json:"reason,omitempty"` // Human-readable explanation
+}
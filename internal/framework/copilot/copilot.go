@@ -0,0 +1,184 @@
+package copilot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const frameworkName = "copilot"
+
+// vsCodeEventNames maps VS Code hook event names onto the Copilot CLI event names used as hook types
+var vsCodeEventNames = map[string]string{
+	"PreToolUse":       preToolUseType,
+	"PostToolUse":      postToolUseType,
+	"UserPromptSubmit": userPromptSubmittedType,
+}
+
+// Framework implements the HookFramework interface for GitHub Copilot agent hooks
+// It accepts both the Copilot CLI / coding agent payloads and the VS Code agent-mode payloads
+type Framework struct {
+	handlers []framework.HookHandler
+}
+
+// Force compile-time check for interface implementation
+var (
+	_ framework.HookFramework      = (*Framework)(nil)
+	_ framework.AuditOnlyFramework = (*Framework)(nil)
+	_ framework.Detector           = (*Framework)(nil)
+)
+
+// NewFramework creates a new Copilot framework instance
+func NewFramework() *Framework {
+	f := &Framework{
+		handlers: []framework.HookHandler{},
+	}
+
+	// Register default handlers
+	f.RegisterHandler(NewPreToolUseHandler())
+	f.RegisterHandler(NewPostToolUseHandler())
+	f.RegisterHandler(NewUserPromptSubmittedHandler())
+
+	return f
+}
+
+// RegisterHandler registers a hook handler with the framework
+func (f *Framework) RegisterHandler(handler framework.HookHandler) {
+	f.handlers = append(f.handlers, handler)
+}
+
+// GetHandler returns the appropriate handler for the given input
+func (f *Framework) GetHandler(input types.HookInput) (framework.HookHandler, error) {
+	for _, handler := range f.handlers {
+		if handler.CanHandle(input) {
+			return handler, nil
+		}
+	}
+	return nil, fmt.Errorf("no handler found for hook type %q", input.HookType)
+}
+
+// ParseInput reads and parses Copilot hook data from stdin
+func (f *Framework) ParseInput(reader io.Reader) (types.HookInput, error) {
+	var rawData map[string]any
+
+	decoder := json.NewDecoder(reader)
+	if err := decoder.Decode(&rawData); err != nil {
+		return types.HookInput{}, fmt.Errorf("failed to decode JSON input; %w", err)
+	}
+
+	hookType, err := eventType(rawData)
+	if err != nil {
+		return types.HookInput{}, err
+	}
+
+	return types.HookInput{
+		Framework: frameworkName,
+		HookType:  hookType,
+		RawData:   rawData,
+	}, nil
+}
+
+// Detect returns true for VS Code payloads (hookEventName) or Copilot CLI payloads
+// Payloads carrying hook_event_name belong to other frameworks even when their fields look like Copilot CLI input
+func (f *Framework) Detect(rawData map[string]any) bool {
+	if _, ok := rawData["hook_event_name"]; ok {
+		return false
+	}
+
+	hookType, err := eventType(rawData)
+	if err != nil {
+		return false
+	}
+
+	_, err = f.GetHandler(types.HookInput{Framework: frameworkName, HookType: hookType})
+	return err == nil
+}
+
+// eventType returns the Copilot event name of a payload
+// VS Code names the event; Copilot CLI does not, so the event is inferred from the payload fields
+func eventType(rawData map[string]any) (string, error) {
+	if hookEventName, ok := rawData["hookEventName"].(string); ok {
+		if hookType, ok := vsCodeEventNames[hookEventName]; ok {
+			return hookType, nil
+		}
+		return hookEventName, nil
+	}
+
+	switch {
+	case rawData["toolResult"] != nil:
+		return postToolUseType, nil
+	case rawData["toolName"] != nil:
+		return preToolUseType, nil
+	case rawData["prompt"] != nil:
+		return userPromptSubmittedType, nil
+	default:
+		return "", fmt.Errorf("unable to determine hook event from input")
+	}
+}
+
+// isVSCode reports whether the input came from VS Code rather than Copilot CLI
+func isVSCode(input types.HookInput) bool {
+	_, ok := input.RawData["hookEventName"].(string)
+	return ok
+}
+
+// FormatOutput formats a decision as JSON for Copilot
+func (f *Framework) FormatOutput(decision types.Decision, input types.HookInput) ([]byte, error) {
+	output := HookOutput{}
+
+	intervene := decision.Block || decision.Action == types.ActionAsk
+
+	switch input.HookType {
+	case preToolUseType:
+		// Tool calls block (or ask) through a permission decision
+		output.PermissionDecision = types.ActionAllow
+		if intervene {
+			output.PermissionDecision = types.ActionDeny
+			if decision.Action == types.ActionAsk {
+				output.PermissionDecision = types.ActionAsk
+			}
+			output.PermissionDecisionReason = decision.Reason
+		}
+
+		if hookEventName, ok := input.RawData["hookEventName"].(string); ok {
+			output.HookSpecificOutput = &HookSpecificOutput{
+				HookEventName:            hookEventName,
+				PermissionDecision:       output.PermissionDecision,
+				PermissionDecisionReason: output.PermissionDecisionReason,
+			}
+		}
+	case postToolUseType, userPromptSubmittedType:
+		// Only VS Code can block these events; Copilot CLI ignores their output
+		if intervene && isVSCode(input) {
+			output.Decision = "block"
+			output.Reason = decision.Reason
+		}
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal output; %w", err)
+	}
+
+	return data, nil
+}
+
+// IsAuditOnly reports whether Copilot ignores the decision for the given input
+// Copilot CLI only honors decisions for preToolUse; VS Code honors all supported events
+func (f *Framework) IsAuditOnly(input types.HookInput) bool {
+	return input.HookType != preToolUseType && !isVSCode(input)
+}
+
+// GetExitCode returns the appropriate exit code for Copilot
+// Blocking is controlled by the JSON permission decision
+func (f *Framework) GetExitCode(decision types.Decision) int {
+	return 0
+}
+
+// GetName returns the framework name
+func (f *Framework) GetName() string {
+	return frameworkName
+}
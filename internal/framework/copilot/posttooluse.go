@@ -0,0 +1,65 @@
+package copilot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const postToolUseType = "postToolUse"
+
+// PostToolUseHandler handles the postToolUse hook
+// It scans tool output before it re-enters the model context
+type PostToolUseHandler struct{}
+
+// Force compile-time check for interface implementation
+var _ framework.HookHandler = (*PostToolUseHandler)(nil)
+
+// NewPostToolUseHandler creates a new postToolUse handler
+func NewPostToolUseHandler() *PostToolUseHandler {
+	return &PostToolUseHandler{}
+}
+
+// ExtractContent extracts the tool result for scanning
+func (h *PostToolUseHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
+	var toolOutput ToolUseInput
+
+	if err := framework.DecodeRawData(input.RawData, &toolOutput); err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to unmarshal postToolUse input; %w", err)
+	}
+
+	toolName, args, err := toolCall(toolOutput)
+	if err != nil {
+		return types.ScanContent{}, err
+	}
+
+	content := types.ScanContent{
+		Type:     "text",
+		Metadata: toolMetadata(toolOutput, toolName),
+	}
+
+	if toolOutput.ToolResult != nil {
+		content.Content = toolOutput.ToolResult.TextResultForLLM
+	} else {
+		content.Content = strings.Join(framework.CollectStrings(toolOutput.VSCodeToolResponse), "\n")
+	}
+
+	if filePath := firstArg(args, pathArgs); filePath != "" {
+		content.Metadata["file_path"] = filePath
+	}
+
+	return content, nil
+}
+
+// GetType returns the hook type name
+func (h *PostToolUseHandler) GetType() string {
+	return postToolUseType
+}
+
+// CanHandle returns true if this handler can process the given hook input
+func (h *PostToolUseHandler) CanHandle(input types.HookInput) bool {
+	return input.Framework == frameworkName && input.HookType == postToolUseType
+}
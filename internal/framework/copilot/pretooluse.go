@@ -0,0 +1,119 @@
+package copilot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const preToolUseType = "preToolUse"
+
+var (
+	// contentArgs are the tool arguments that carry commands or file content across Copilot CLI and VS Code tools
+	// (e.g. bash/run_in_terminal "command", create "file_text", create_file "content", edit "new_str",
+	// replace_string_in_file "newString", insert_edit_into_file "code")
+	contentArgs = []string{"command", "file_text", "content", "new_str", "new_string", "newString", "code"}
+
+	// pathArgs are the tool arguments that name the target file
+	pathArgs = []string{"path", "file_path", "filePath"}
+)
+
+// PreToolUseHandler handles the preToolUse hook
+// Commands and file content in the tool arguments are scanned; tools without them yield empty content
+type PreToolUseHandler struct{}
+
+// Force compile-time check for interface implementation
+var _ framework.HookHandler = (*PreToolUseHandler)(nil)
+
+// NewPreToolUseHandler creates a new preToolUse handler
+func NewPreToolUseHandler() *PreToolUseHandler {
+	return &PreToolUseHandler{}
+}
+
+// ExtractContent extracts the tool arguments that are about to be executed for scanning
+func (h *PreToolUseHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
+	var toolInput ToolUseInput
+
+	if err := framework.DecodeRawData(input.RawData, &toolInput); err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to unmarshal preToolUse input; %w", err)
+	}
+
+	toolName, args, err := toolCall(toolInput)
+	if err != nil {
+		return types.ScanContent{}, err
+	}
+
+	content := types.ScanContent{
+		Type:     "text",
+		Content:  joinArgs(args, contentArgs),
+		Metadata: toolMetadata(toolInput, toolName),
+	}
+
+	// Pass the target path through so findings report it and the scanner can preserve the file type
+	if filePath := firstArg(args, pathArgs); filePath != "" {
+		content.Metadata["file_path"] = filePath
+	}
+
+	return content, nil
+}
+
+// toolCall returns the tool name and arguments from either payload format
+// Copilot CLI encodes toolArgs as a JSON string
+func toolCall(toolInput ToolUseInput) (string, map[string]any, error) {
+	if toolInput.ToolName == "" {
+		return toolInput.VSCodeToolName, toolInput.VSCodeToolInput, nil
+	}
+
+	args := map[string]any{}
+	if toolInput.ToolArgs != "" {
+		if err := json.Unmarshal([]byte(toolInput.ToolArgs), &args); err != nil {
+			return "", nil, fmt.Errorf("failed to parse toolArgs; %w", err)
+		}
+	}
+
+	return toolInput.ToolName, args, nil
+}
+
+// toolMetadata returns the content metadata for a tool invocation
+func toolMetadata(toolInput ToolUseInput, toolName string) map[string]string {
+	return map[string]string{
+		"session_id": toolInput.SessionID,
+		"cwd":        toolInput.CWD,
+		"tool_name":  toolName,
+	}
+}
+
+// joinArgs joins the non-empty string values of the given argument keys with newlines
+func joinArgs(args map[string]any, keys []string) string {
+	var parts []string
+	for _, key := range keys {
+		if value, ok := args[key].(string); ok && value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// firstArg returns the first non-empty string value of the given argument keys
+func firstArg(args map[string]any, keys []string) string {
+	for _, key := range keys {
+		if value, ok := args[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// GetType returns the hook type name
+func (h *PreToolUseHandler) GetType() string {
+	return preToolUseType
+}
+
+// CanHandle returns true if this handler can process the given hook input
+func (h *PreToolUseHandler) CanHandle(input types.HookInput) bool {
+	return input.Framework == frameworkName && input.HookType == preToolUseType
+}
@@ -0,0 +1,44 @@
+package copilot
+
+// ToolUseInput represents the input structure for preToolUse and postToolUse hooks
+// Copilot CLI sends camelCase fields with toolArgs as a JSON string; VS Code sends snake_case fields with a tool_input object
+type ToolUseInput struct {
+	SessionID          string         `json:"sessionId"`
+	CWD                string         `json:"cwd"`
+	ToolName           string         `json:"toolName"`
+	ToolArgs           string         `json:"toolArgs"`
+	ToolResult         *ToolResult    `json:"toolResult"`
+	VSCodeToolName     string         `json:"tool_name"`
+	VSCodeToolInput    map[string]any `json:"tool_input"`
+	VSCodeToolResponse any            `json:"tool_response"`
+}
+
+// ToolResult represents the result of a tool invocation in postToolUse hooks
+type ToolResult struct {
+	ResultType       string `json:"resultType"` // "success", "failure" or "denied"
+	TextResultForLLM string `json:"textResultForLlm"`
+}
+
+// UserPromptSubmittedInput represents the input structure for userPromptSubmitted hook
+type UserPromptSubmittedInput struct {
+	SessionID string `json:"sessionId"`
+	CWD       string `json:"cwd"`
+	Prompt    string `json:"prompt"`
+}
+
+// HookOutput represents the output structure for Copilot hooks
+// Only preToolUse reads the permission fields; VS Code reads them from hookSpecificOutput and blocks prompts through decision
+type HookOutput struct {
+	PermissionDecision       string              `json:"permissionDecision,omitempty"` // "allow", "deny" or "ask"
+	PermissionDecisionReason string              `json:"permissionDecisionReason,omitempty"`
+	Decision                 string              `json:"decision,omitempty"` // VS Code only: "block"
+	Reason                   string              `json:"reason,omitempty"`   // VS Code only
+	HookSpecificOutput       *HookSpecificOutput `json:"hookSpecificOutput,omitempty"`
+}
+
+// HookSpecificOutput contains VS Code hook-specific output fields
+type HookSpecificOutput struct {
+	HookEventName            string `json:"hookEventName"`
+	PermissionDecision       string `json:"permissionDecision,omitempty"`
+	PermissionDecisionReason string `json:"permissionDecisionReason,omitempty"`
+}
@@ -0,0 +1,50 @@
+package copilot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const userPromptSubmittedType = "userPromptSubmitted"
+
+// UserPromptSubmittedHandler handles the userPromptSubmitted hook
+type UserPromptSubmittedHandler struct{}
+
+// Force compile-time check for interface implementation
+var _ framework.HookHandler = (*UserPromptSubmittedHandler)(nil)
+
+// NewUserPromptSubmittedHandler creates a new userPromptSubmitted handler
+func NewUserPromptSubmittedHandler() *UserPromptSubmittedHandler {
+	return &UserPromptSubmittedHandler{}
+}
+
+// ExtractContent extracts the prompt text for scanning
+func (h *UserPromptSubmittedHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
+	var promptInput UserPromptSubmittedInput
+
+	if err := framework.DecodeRawData(input.RawData, &promptInput); err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to unmarshal userPromptSubmitted input; %w", err)
+	}
+
+	return types.ScanContent{
+		Type:    "text",
+		Content: promptInput.Prompt,
+		Metadata: map[string]string{
+			"session_id": promptInput.SessionID,
+			"cwd":        promptInput.CWD,
+		},
+	}, nil
+}
+
+// GetType returns the hook type name
+func (h *UserPromptSubmittedHandler) GetType() string {
+	return userPromptSubmittedType
+}
+
+// CanHandle returns true if this handler can process the given hook input
+func (h *UserPromptSubmittedHandler) CanHandle(input types.HookInput) bool {
+	return input.Framework == frameworkName && input.HookType == userPromptSubmittedType
+}
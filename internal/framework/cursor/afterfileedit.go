@@ -0,0 +1,69 @@
+package cursor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const (
+	afterFileEditType = "afterFileEdit"
+
+	// afterFileEditGuidance is appended to the agent message after an edit introduced secrets
+	afterFileEditGuidance = "\n\nThe edit above wrote sensitive values to the file. Remove them and reference " +
+		"them through environment variables or a secrets manager instead."
+)
+
+// AfterFileEditHandler handles the afterFileEdit hook
+// The edit has already been applied, so findings are reported rather than blocked
+type AfterFileEditHandler struct{}
+
+// Force compile-time check for interface implementation
+var _ framework.HookHandler = (*AfterFileEditHandler)(nil)
+
+// NewAfterFileEditHandler creates a new afterFileEdit handler
+func NewAfterFileEditHandler() *AfterFileEditHandler {
+	return &AfterFileEditHandler{}
+}
+
+// ExtractContent extracts the text introduced by the edits for scanning
+func (h *AfterFileEditHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
+	var editInput AfterFileEditInput
+
+	if err := framework.DecodeRawData(input.RawData, &editInput); err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to unmarshal afterFileEdit input; %w", err)
+	}
+
+	parts := make([]string, 0, len(editInput.Edits))
+	for _, edit := range editInput.Edits {
+		if edit.NewString != "" {
+			parts = append(parts, edit.NewString)
+		}
+	}
+
+	content := types.ScanContent{
+		Type:     "text",
+		Content:  strings.Join(parts, "\n"),
+		Metadata: baseMetadata(editInput.ConversationID, editInput.GenerationID, editInput.WorkspaceRoots),
+	}
+
+	// Pass the path through so findings report it and the scanner can preserve the file type
+	if editInput.FilePath != "" {
+		content.Metadata["file_path"] = editInput.FilePath
+	}
+
+	return content, nil
+}
+
+// GetType returns the hook type name
+func (h *AfterFileEditHandler) GetType() string {
+	return afterFileEditType
+}
+
+// CanHandle returns true if this handler can process the given hook input
+func (h *AfterFileEditHandler) CanHandle(input types.HookInput) bool {
+	return input.Framework == frameworkName && input.HookType == afterFileEditType
+}
@@ -0,0 +1,61 @@
+package cursor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const (
+	beforeReadFileType = "beforeReadFile"
+
+	// beforeReadFileGuidance is appended to the agent message when a file read is denied because of secrets
+	beforeReadFileGuidance = "\n\nThe file contains sensitive values and was not read. Do not attempt to read it " +
+		"through other tools or commands."
+)
+
+// BeforeReadFileHandler handles the beforeReadFile hook
+// It scans file content before it is sent to the model
+type BeforeReadFileHandler struct{}
+
+// Force compile-time check for interface implementation
+var _ framework.HookHandler = (*BeforeReadFileHandler)(nil)
+
+// NewBeforeReadFileHandler creates a new beforeReadFile handler
+func NewBeforeReadFileHandler() *BeforeReadFileHandler {
+	return &BeforeReadFileHandler{}
+}
+
+// ExtractContent extracts the file content that is about to be read for scanning
+func (h *BeforeReadFileHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
+	var readInput BeforeReadFileInput
+
+	if err := framework.DecodeRawData(input.RawData, &readInput); err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to unmarshal beforeReadFile input; %w", err)
+	}
+
+	content := types.ScanContent{
+		Type:     "text",
+		Content:  readInput.Content,
+		Metadata: baseMetadata(readInput.ConversationID, readInput.GenerationID, readInput.WorkspaceRoots),
+	}
+
+	// Pass the path through so findings report it and the scanner can preserve the file type
+	if readInput.FilePath != "" {
+		content.Metadata["file_path"] = readInput.FilePath
+	}
+
+	return content, nil
+}
+
+// GetType returns the hook type name
+func (h *BeforeReadFileHandler) GetType() string {
+	return beforeReadFileType
+}
+
+// CanHandle returns true if this handler can process the given hook input
+func (h *BeforeReadFileHandler) CanHandle(input types.HookInput) bool {
+	return input.Framework == frameworkName && input.HookType == beforeReadFileType
+}
@@ -0,0 +1,52 @@
+package cursor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const beforeShellExecutionType = "beforeShellExecution"
+
+// BeforeShellExecutionHandler handles the beforeShellExecution hook
+type BeforeShellExecutionHandler struct{}
+
+// Force compile-time check for interface implementation
+var _ framework.HookHandler = (*BeforeShellExecutionHandler)(nil)
+
+// NewBeforeShellExecutionHandler creates a new beforeShellExecution handler
+func NewBeforeShellExecutionHandler() *BeforeShellExecutionHandler {
+	return &BeforeShellExecutionHandler{}
+}
+
+// ExtractContent extracts the shell command that is about to run for scanning
+func (h *BeforeShellExecutionHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
+	var shellInput BeforeShellExecutionInput
+
+	if err := framework.DecodeRawData(input.RawData, &shellInput); err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to unmarshal beforeShellExecution input; %w", err)
+	}
+
+	metadata := baseMetadata(shellInput.ConversationID, shellInput.GenerationID, shellInput.WorkspaceRoots)
+	if shellInput.CWD != "" {
+		metadata["cwd"] = shellInput.CWD
+	}
+
+	return types.ScanContent{
+		Type:     "text",
+		Content:  shellInput.Command,
+		Metadata: metadata,
+	}, nil
+}
+
+// GetType returns the hook type name
+func (h *BeforeShellExecutionHandler) GetType() string {
+	return beforeShellExecutionType
+}
+
+// CanHandle returns true if this handler can process the given hook input
+func (h *BeforeShellExecutionHandler) CanHandle(input types.HookInput) bool {
+	return input.Framework == frameworkName && input.HookType == beforeShellExecutionType
+}
@@ -0,0 +1,53 @@
+package cursor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const (
+	beforeSubmitPromptType = "beforeSubmitPrompt"
+
+	// redactedPromptNotice introduces the redacted prompt offered for resubmission
+	redactedPromptNotice = "\n\nA redacted copy of your prompt with the secrets removed is below. " +
+		"Copy it to resubmit:\n\n"
+)
+
+// BeforeSubmitPromptHandler handles the beforeSubmitPrompt hook
+type BeforeSubmitPromptHandler struct{}
+
+// Force compile-time check for interface implementation
+var _ framework.HookHandler = (*BeforeSubmitPromptHandler)(nil)
+
+// NewBeforeSubmitPromptHandler creates a new beforeSubmitPrompt handler
+func NewBeforeSubmitPromptHandler() *BeforeSubmitPromptHandler {
+	return &BeforeSubmitPromptHandler{}
+}
+
+// ExtractContent extracts the prompt text for scanning
+func (h *BeforeSubmitPromptHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
+	var promptInput BeforeSubmitPromptInput
+
+	if err := framework.DecodeRawData(input.RawData, &promptInput); err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to unmarshal beforeSubmitPrompt input; %w", err)
+	}
+
+	return types.ScanContent{
+		Type:     "text",
+		Content:  promptInput.Prompt,
+		Metadata: baseMetadata(promptInput.ConversationID, promptInput.GenerationID, promptInput.WorkspaceRoots),
+	}, nil
+}
+
+// GetType returns the hook type name
+func (h *BeforeSubmitPromptHandler) GetType() string {
+	return beforeSubmitPromptType
+}
+
+// CanHandle returns true if this handler can process the given hook input
+func (h *BeforeSubmitPromptHandler) CanHandle(input types.HookInput) bool {
+	return input.Framework == frameworkName && input.HookType == beforeSubmitPromptType
+}
@@ -0,0 +1,167 @@
+package cursor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const frameworkName = "cursor"
+
+// Framework implements the HookFramework interface for Cursor agent hooks
+type Framework struct {
+	handlers []framework.HookHandler
+}
+
+// Force compile-time check for interface implementation
+var (
+	_ framework.HookFramework = (*Framework)(nil)
+	_ framework.Detector      = (*Framework)(nil)
+)
+
+// NewFramework creates a new Cursor framework instance
+func NewFramework() *Framework {
+	f := &Framework{
+		handlers: []framework.HookHandler{},
+	}
+
+	// Register default handlers
+	f.RegisterHandler(NewBeforeSubmitPromptHandler())
+	f.RegisterHandler(NewBeforeShellExecutionHandler())
+	f.RegisterHandler(NewBeforeReadFileHandler())
+	f.RegisterHandler(NewAfterFileEditHandler())
+
+	return f
+}
+
+// RegisterHandler registers a hook handler with the framework
+func (f *Framework) RegisterHandler(handler framework.HookHandler) {
+	f.handlers = append(f.handlers, handler)
+}
+
+// GetHandler returns the appropriate handler for the given input
+func (f *Framework) GetHandler(input types.HookInput) (framework.HookHandler, error) {
+	for _, handler := range f.handlers {
+		if handler.CanHandle(input) {
+			return handler, nil
+		}
+	}
+	return nil, fmt.Errorf("no handler found for hook type %q", input.HookType)
+}
+
+// ParseInput reads and parses Cursor hook data from stdin
+func (f *Framework) ParseInput(reader io.Reader) (types.HookInput, error) {
+	var rawData map[string]any
+
+	decoder := json.NewDecoder(reader)
+	if err := decoder.Decode(&rawData); err != nil {
+		return types.HookInput{}, fmt.Errorf("failed to decode JSON input; %w", err)
+	}
+
+	// Extract hook event name
+	hookEventName, ok := rawData["hook_event_name"].(string)
+	if !ok {
+		return types.HookInput{}, fmt.Errorf("missing or invalid hook_event_name")
+	}
+
+	return types.HookInput{
+		Framework: frameworkName,
+		HookType:  hookEventName,
+		RawData:   rawData,
+	}, nil
+}
+
+// Detect returns true for payloads with a Cursor hook_event_name
+func (f *Framework) Detect(rawData map[string]any) bool {
+	hookEventName, ok := rawData["hook_event_name"].(string)
+	if !ok {
+		return false
+	}
+
+	_, err := f.GetHandler(types.HookInput{Framework: frameworkName, HookType: hookEventName})
+	return err == nil
+}
+
+// FormatOutput formats a decision as JSON for Cursor
+func (f *Framework) FormatOutput(decision types.Decision, input types.HookInput) ([]byte, error) {
+	output := HookOutput{}
+
+	intervene := decision.Block || decision.Action == types.ActionAsk
+
+	switch input.HookType {
+	case beforeSubmitPromptType:
+		// Prompts cannot be confirmed, so ask blocks; a redacted copy (redact mode) is offered for resubmission
+		proceed := !intervene
+		output.Continue = &proceed
+		if intervene {
+			output.UserMessage = decision.Reason
+			if decision.RedactedContent != "" {
+				output.UserMessage += redactedPromptNotice + decision.RedactedContent
+			}
+		}
+	case beforeShellExecutionType:
+		// Shell commands can ask the human to confirm
+		output.Permission = types.ActionAllow
+		if intervene {
+			output.Permission = types.ActionDeny
+			if decision.Action == types.ActionAsk {
+				output.Permission = types.ActionAsk
+			}
+			output.UserMessage = decision.Reason
+			output.AgentMessage = decision.Reason
+		}
+	case beforeReadFileType:
+		// File reads only support allow or deny, so ask denies rather than silently allowing
+		output.Permission = types.ActionAllow
+		if intervene {
+			output.Permission = types.ActionDeny
+			output.UserMessage = decision.Reason
+			output.AgentMessage = decision.Reason
+			if framework.HasFindings(decision) {
+				output.AgentMessage += beforeReadFileGuidance
+			}
+		}
+	case afterFileEditType:
+		// The edit has already been applied, so findings are reported without a permission
+		if intervene {
+			output.UserMessage = decision.Reason
+			output.AgentMessage = decision.Reason
+			if framework.HasFindings(decision) {
+				output.AgentMessage += afterFileEditGuidance
+			}
+		}
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal output; %w", err)
+	}
+
+	return data, nil
+}
+
+// baseMetadata returns the content metadata shared by every Cursor hook
+func baseMetadata(conversationID, generationID string, workspaceRoots []string) map[string]string {
+	metadata := map[string]string{
+		"conversation_id": conversationID,
+		"generation_id":   generationID,
+	}
+	if len(workspaceRoots) > 0 {
+		metadata["cwd"] = workspaceRoots[0]
+	}
+	return metadata
+}
+
+// GetExitCode returns the appropriate exit code for Cursor
+// Blocking is controlled by the JSON "permission" and "continue" fields
+func (f *Framework) GetExitCode(decision types.Decision) int {
+	return 0
+}
+
+// GetName returns the framework name
+func (f *Framework) GetName() string {
+	return frameworkName
+}
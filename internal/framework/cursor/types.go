@@ -0,0 +1,63 @@
+package cursor
+
+// BeforeSubmitPromptInput represents the input structure for beforeSubmitPrompt hook
+type BeforeSubmitPromptInput struct {
+	ConversationID string       `json:"conversation_id"`
+	GenerationID   string       `json:"generation_id"`
+	HookEventName  string       `json:"hook_event_name"`
+	WorkspaceRoots []string     `json:"workspace_roots"`
+	Prompt         string       `json:"prompt"`
+	Attachments    []Attachment `json:"attachments"`
+}
+
+// BeforeShellExecutionInput represents the input structure for beforeShellExecution hook
+type BeforeShellExecutionInput struct {
+	ConversationID string   `json:"conversation_id"`
+	GenerationID   string   `json:"generation_id"`
+	HookEventName  string   `json:"hook_event_name"`
+	WorkspaceRoots []string `json:"workspace_roots"`
+	Command        string   `json:"command"`
+	CWD            string   `json:"cwd"`
+}
+
+// BeforeReadFileInput represents the input structure for beforeReadFile hook
+type BeforeReadFileInput struct {
+	ConversationID string       `json:"conversation_id"`
+	GenerationID   string       `json:"generation_id"`
+	HookEventName  string       `json:"hook_event_name"`
+	WorkspaceRoots []string     `json:"workspace_roots"`
+	FilePath       string       `json:"file_path"`
+	Content        string       `json:"content"`
+	Attachments    []Attachment `json:"attachments"`
+}
+
+// AfterFileEditInput represents the input structure for afterFileEdit hook
+type AfterFileEditInput struct {
+	ConversationID string     `json:"conversation_id"`
+	GenerationID   string     `json:"generation_id"`
+	HookEventName  string     `json:"hook_event_name"`
+	WorkspaceRoots []string   `json:"workspace_roots"`
+	FilePath       string     `json:"file_path"`
+	Edits          []FileEdit `json:"edits"`
+}
+
+// Attachment represents a file or rule attached to a prompt or file read
+type Attachment struct {
+	Type     string `json:"type"` // "file" or "rule"
+	FilePath string `json:"file_path"`
+}
+
+// FileEdit represents a single edit applied by the agent
+type FileEdit struct {
+	OldString string `json:"old_string"`
+	NewString string `json:"new_string"`
+}
+
+// HookOutput represents the output structure for Cursor hooks
+// Each hook reads only the fields it supports; unset fields are omitted
+type HookOutput struct {
+	Continue     *bool  `json:"continue,omitempty"`     // beforeSubmitPrompt only
+	Permission   string `json:"permission,omitempty"`   // "allow", "deny" or "ask" (ask is beforeShellExecution only)
+	UserMessage  string `json:"userMessage,omitempty"`  // Shown to the user in the Cursor UI
+	AgentMessage string `json:"agentMessage,omitempty"` // Fed back to the agent
+}
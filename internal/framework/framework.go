@@ -20,6 +20,16 @@ type HookFramework interface {
 
 	// GetName returns the framework name
 	GetName() string
+
+	// GetHandler returns the handler for the hook type of the input
+	GetHandler(input types.HookInput) (HookHandler, error)
+}
+
+// Detector is implemented by frameworks that can recognize their own hook payloads
+// It is used to pick a framework when none is specified explicitly
+type Detector interface {
+	// Detect returns true if the decoded hook payload belongs to this framework
+	Detect(rawData map[string]any) bool
 }
 
 // HookHandler defines the interface for specific hook type handlers
@@ -33,3 +43,17 @@ type HookHandler interface {
 	// CanHandle returns true if this handler can process the given hook input
 	CanHandle(input types.HookInput) bool
 }
+
+// ContentCommitter is implemented by handlers that track how far they have extracted a growing source
+// CommitContent is called only after the content was scanned, so content whose scan failed is extracted again
+type ContentCommitter interface {
+	// CommitContent records that the content extracted for the input has been scanned
+	CommitContent(input types.HookInput, content types.ScanContent) error
+}
+
+// AuditOnlyFramework is implemented by frameworks that cannot block the actions they report
+// Decisions for these frameworks are recorded by remediation but never enforced
+type AuditOnlyFramework interface {
+	// IsAuditOnly returns true if decisions for the given input cannot be enforced
+	IsAuditOnly(input types.HookInput) bool
+}
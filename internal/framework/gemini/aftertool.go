@@ -0,0 +1,77 @@
+package gemini
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const (
+	afterToolType = "AfterTool"
+
+	// afterToolGuidance is appended to the reason fed back to the model after a tool result contained secrets
+	afterToolGuidance = "\n\nThe tool output contained sensitive values and was withheld. Do not attempt to " +
+		"retrieve, repeat, or reuse these values in responses, files, or subsequent tool calls."
+)
+
+// AfterToolHandler handles the AfterTool hook
+// It scans tool output before it re-enters the model context
+type AfterToolHandler struct{}
+
+// Force compile-time check for interface implementation
+var _ framework.HookHandler = (*AfterToolHandler)(nil)
+
+// NewAfterToolHandler creates a new AfterTool handler
+func NewAfterToolHandler() *AfterToolHandler {
+	return &AfterToolHandler{}
+}
+
+// ExtractContent extracts the tool response for scanning
+func (h *AfterToolHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
+	var toolOutput AfterToolInput
+
+	if err := framework.DecodeRawData(input.RawData, &toolOutput); err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to unmarshal AfterTool input; %w", err)
+	}
+
+	content := types.ScanContent{
+		Type: "text",
+		Metadata: map[string]string{
+			"session_id":      toolOutput.SessionID,
+			"transcript_path": toolOutput.TranscriptPath,
+			"cwd":             toolOutput.CWD,
+			"tool_name":       toolOutput.ToolName,
+		},
+	}
+
+	// llmContent is what reaches the model; fall back to every string when it is absent
+	response, _ := toolOutput.ToolResponse.(map[string]any)
+	if llmContent, ok := response["llmContent"]; ok {
+		content.Content = strings.Join(framework.CollectStrings(llmContent), "\n")
+	} else {
+		content.Content = strings.Join(framework.CollectStrings(toolOutput.ToolResponse), "\n")
+	}
+
+	// read_file names its target absolute_path, other file tools use file_path
+	for _, key := range []string{"file_path", "absolute_path"} {
+		if filePath, ok := toolOutput.ToolInput[key].(string); ok && filePath != "" {
+			content.Metadata["file_path"] = filePath
+			break
+		}
+	}
+
+	return content, nil
+}
+
+// GetType returns the hook type name
+func (h *AfterToolHandler) GetType() string {
+	return afterToolType
+}
+
+// CanHandle returns true if this handler can process the given hook input
+func (h *AfterToolHandler) CanHandle(input types.HookInput) bool {
+	return input.Framework == frameworkName && input.HookType == afterToolType
+}
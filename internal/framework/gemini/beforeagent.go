@@ -0,0 +1,57 @@
+package gemini
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const (
+	beforeAgentType = "BeforeAgent"
+
+	// redactedPromptNotice introduces the redacted prompt offered for resubmission
+	redactedPromptNotice = "\n\nA redacted copy of your prompt with the secrets removed is below. " +
+		"Copy it to resubmit:\n\n"
+)
+
+// BeforeAgentHandler handles the BeforeAgent hook, fired when a prompt is submitted
+type BeforeAgentHandler struct{}
+
+// Force compile-time check for interface implementation
+var _ framework.HookHandler = (*BeforeAgentHandler)(nil)
+
+// NewBeforeAgentHandler creates a new BeforeAgent handler
+func NewBeforeAgentHandler() *BeforeAgentHandler {
+	return &BeforeAgentHandler{}
+}
+
+// ExtractContent extracts the prompt text for scanning
+func (h *BeforeAgentHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
+	var agentInput BeforeAgentInput
+
+	if err := framework.DecodeRawData(input.RawData, &agentInput); err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to unmarshal BeforeAgent input; %w", err)
+	}
+
+	return types.ScanContent{
+		Type:    "text",
+		Content: agentInput.Prompt,
+		Metadata: map[string]string{
+			"session_id":      agentInput.SessionID,
+			"transcript_path": agentInput.TranscriptPath,
+			"cwd":             agentInput.CWD,
+		},
+	}, nil
+}
+
+// GetType returns the hook type name
+func (h *BeforeAgentHandler) GetType() string {
+	return beforeAgentType
+}
+
+// CanHandle returns true if this handler can process the given hook input
+func (h *BeforeAgentHandler) CanHandle(input types.HookInput) bool {
+	return input.Framework == frameworkName && input.HookType == beforeAgentType
+}
@@ -0,0 +1,74 @@
+package gemini
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const (
+	beforeToolType = "BeforeTool"
+
+	toolRunShellCommand = "run_shell_command"
+	toolWriteFile       = "write_file"
+	toolReplace         = "replace"
+)
+
+// BeforeToolHandler handles the BeforeTool hook
+// Only tool calls whose input may carry secrets are scanned; other tools yield empty content
+type BeforeToolHandler struct{}
+
+// Force compile-time check for interface implementation
+var _ framework.HookHandler = (*BeforeToolHandler)(nil)
+
+// NewBeforeToolHandler creates a new BeforeTool handler
+func NewBeforeToolHandler() *BeforeToolHandler {
+	return &BeforeToolHandler{}
+}
+
+// ExtractContent extracts the tool input that is about to be executed for scanning
+func (h *BeforeToolHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
+	var toolInput BeforeToolInput
+
+	if err := framework.DecodeRawData(input.RawData, &toolInput); err != nil {
+		return types.ScanContent{}, fmt.Errorf("failed to unmarshal BeforeTool input; %w", err)
+	}
+
+	content := types.ScanContent{
+		Type: "text",
+		Metadata: map[string]string{
+			"session_id":      toolInput.SessionID,
+			"transcript_path": toolInput.TranscriptPath,
+			"cwd":             toolInput.CWD,
+			"tool_name":       toolInput.ToolName,
+		},
+	}
+
+	switch toolInput.ToolName {
+	case toolRunShellCommand:
+		content.Content, _ = toolInput.ToolInput["command"].(string)
+	case toolWriteFile:
+		content.Content, _ = toolInput.ToolInput["content"].(string)
+	case toolReplace:
+		content.Content, _ = toolInput.ToolInput["new_string"].(string)
+	}
+
+	// Pass the target path through so findings report it and the scanner can preserve the file type
+	if filePath, ok := toolInput.ToolInput["file_path"].(string); ok && filePath != "" {
+		content.Metadata["file_path"] = filePath
+	}
+
+	return content, nil
+}
+
+// GetType returns the hook type name
+func (h *BeforeToolHandler) GetType() string {
+	return beforeToolType
+}
+
+// CanHandle returns true if this handler can process the given hook input
+func (h *BeforeToolHandler) CanHandle(input types.HookInput) bool {
+	return input.Framework == frameworkName && input.HookType == beforeToolType
+}
@@ -0,0 +1,144 @@
+package gemini
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const (
+	frameworkName = "gemini"
+
+	// exitCodeBlock tells Gemini CLI to block the action, using stderr as the reason
+	exitCodeBlock = 2
+)
+
+// Framework implements the HookFramework interface for Gemini CLI
+type Framework struct {
+	handlers []framework.HookHandler
+}
+
+// Force compile-time check for interface implementation
+var (
+	_ framework.HookFramework = (*Framework)(nil)
+	_ framework.Detector      = (*Framework)(nil)
+)
+
+// NewFramework creates a new Gemini framework instance
+func NewFramework() *Framework {
+	f := &Framework{
+		handlers: []framework.HookHandler{},
+	}
+
+	// Register default handlers
+	f.RegisterHandler(NewBeforeAgentHandler())
+	f.RegisterHandler(NewBeforeToolHandler())
+	f.RegisterHandler(NewAfterToolHandler())
+
+	return f
+}
+
+// RegisterHandler registers a hook handler with the framework
+func (f *Framework) RegisterHandler(handler framework.HookHandler) {
+	f.handlers = append(f.handlers, handler)
+}
+
+// GetHandler returns the appropriate handler for the given input
+func (f *Framework) GetHandler(input types.HookInput) (framework.HookHandler, error) {
+	for _, handler := range f.handlers {
+		if handler.CanHandle(input) {
+			return handler, nil
+		}
+	}
+	return nil, fmt.Errorf("no handler found for hook type %q", input.HookType)
+}
+
+// ParseInput reads and parses Gemini CLI hook data from stdin
+func (f *Framework) ParseInput(reader io.Reader) (types.HookInput, error) {
+	var rawData map[string]any
+
+	decoder := json.NewDecoder(reader)
+	if err := decoder.Decode(&rawData); err != nil {
+		return types.HookInput{}, fmt.Errorf("failed to decode JSON input; %w", err)
+	}
+
+	// Extract hook event name
+	hookEventName, ok := rawData["hook_event_name"].(string)
+	if !ok {
+		return types.HookInput{}, fmt.Errorf("missing or invalid hook_event_name")
+	}
+
+	return types.HookInput{
+		Framework: frameworkName,
+		HookType:  hookEventName,
+		RawData:   rawData,
+	}, nil
+}
+
+// Detect returns true for payloads with a Gemini CLI hook_event_name
+func (f *Framework) Detect(rawData map[string]any) bool {
+	hookEventName, ok := rawData["hook_event_name"].(string)
+	if !ok {
+		return false
+	}
+
+	_, err := f.GetHandler(types.HookInput{Framework: frameworkName, HookType: hookEventName})
+	return err == nil
+}
+
+// FormatOutput formats a decision as JSON for Gemini CLI
+func (f *Framework) FormatOutput(decision types.Decision, input types.HookInput) ([]byte, error) {
+	output := HookOutput{
+		Decision: types.ActionAllow,
+	}
+
+	if decision.Block || decision.Action == types.ActionAsk {
+		output.Decision = types.ActionDeny
+		output.Reason = decision.Reason
+		output.SystemMessage = decision.Reason
+
+		switch input.HookType {
+		case beforeToolType:
+			// Only tool calls can ask the human to confirm; other hooks block rather than silently allow
+			if decision.Action == types.ActionAsk {
+				output.Decision = types.ActionAsk
+			}
+		case afterToolType:
+			// The tool already ran, so the reason replaces its result with instructions not to reuse the secret
+			if framework.HasFindings(decision) {
+				output.Reason += afterToolGuidance
+			}
+		case beforeAgentType:
+			// The original prompt is blocked; a redacted copy (redact mode) is offered for resubmission
+			if decision.RedactedContent != "" {
+				output.Reason += redactedPromptNotice + decision.RedactedContent
+			}
+			output.SystemMessage = output.Reason
+		}
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal output; %w", err)
+	}
+
+	return data, nil
+}
+
+// GetExitCode returns the appropriate exit code for Gemini CLI
+// Gemini CLI treats exit 2 as a blocking error and shows stderr as the reason, so denials exit 2
+// Ask decisions exit 0 so the "ask" decision in the JSON output is honored
+func (f *Framework) GetExitCode(decision types.Decision) int {
+	if decision.Block {
+		return exitCodeBlock
+	}
+	return 0
+}
+
+// GetName returns the framework name
+func (f *Framework) GetName() string {
+	return frameworkName
+}
@@ -0,0 +1,48 @@
+package gemini
+
+// BeforeAgentInput represents the input structure for BeforeAgent hook
+type BeforeAgentInput struct {
+	SessionID      string `json:"session_id"`
+	TranscriptPath string `json:"transcript_path"`
+	CWD            string `json:"cwd"`
+	HookEventName  string `json:"hook_event_name"`
+	Timestamp      string `json:"timestamp"`
+	Prompt         string `json:"prompt"`
+}
+
+// BeforeToolInput represents the input structure for BeforeTool hook
+type BeforeToolInput struct {
+	SessionID      string         `json:"session_id"`
+	TranscriptPath string         `json:"transcript_path"`
+	CWD            string         `json:"cwd"`
+	HookEventName  string         `json:"hook_event_name"`
+	Timestamp      string         `json:"timestamp"`
+	ToolName       string         `json:"tool_name"`
+	ToolInput      map[string]any `json:"tool_input"`
+}
+
+// AfterToolInput represents the input structure for AfterTool hook
+type AfterToolInput struct {
+	SessionID      string         `json:"session_id"`
+	TranscriptPath string         `json:"transcript_path"`
+	CWD            string         `json:"cwd"`
+	HookEventName  string         `json:"hook_event_name"`
+	Timestamp      string         `json:"timestamp"`
+	ToolName       string         `json:"tool_name"`
+	ToolInput      map[string]any `json:"tool_input"`
+	ToolResponse   any            `json:"tool_response"`
+}
+
+// HookOutput represents the common output structure for Gemini CLI hooks
+type HookOutput struct {
+	Decision           string              `json:"decision,omitempty"` // "allow", "deny" or "ask"
+	Reason             string              `json:"reason,omitempty"`
+	SystemMessage      string              `json:"systemMessage,omitempty"`
+	HookSpecificOutput *HookSpecificOutput `json:"hookSpecificOutput,omitempty"`
+}
+
+// HookSpecificOutput contains hook-specific output fields
+type HookSpecificOutput struct {
+	HookEventName     string `json:"hookEventName,omitempty"`
+	AdditionalContext string `json:"additionalContext,omitempty"`
+}
@@ -0,0 +1,66 @@
+package generic
+
+import (
+	"context"
+	"strings"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+// EventHandler handles a single configured event, scanning the strings at its field selectors
+type EventHandler struct {
+	framework      string // Name of the framework the event belongs to ("generic" or "generic:<profile>")
+	event          config.GenericEventConfig
+	sessionIDField string
+}
+
+// Force compile-time check for interface implementation
+var _ framework.HookHandler = (*EventHandler)(nil)
+
+// NewEventHandler creates a new handler for an event configured for the named framework
+func NewEventHandler(frameworkName string, event config.GenericEventConfig, sessionIDField string) *EventHandler {
+	return &EventHandler{
+		framework:      frameworkName,
+		event:          event,
+		sessionIDField: sessionIDField,
+	}
+}
+
+// ExtractContent extracts every string selected by the event's fields for scanning
+func (h *EventHandler) ExtractContent(ctx context.Context, input types.HookInput) (types.ScanContent, error) {
+	var parts []string
+	for _, field := range h.event.Fields {
+		for _, value := range selectPath(input.RawData, field) {
+			parts = append(parts, framework.CollectStrings(value)...)
+		}
+	}
+
+	content := types.ScanContent{
+		Type:     "text",
+		Content:  strings.Join(parts, "\n"),
+		Metadata: map[string]string{},
+	}
+
+	if sessionID := selectString(input.RawData, h.sessionIDField); sessionID != "" {
+		content.Metadata["session_id"] = sessionID
+	}
+
+	// Pass the target path through so findings report it and the scanner can preserve the file type
+	if filePath := selectString(input.RawData, h.event.FilePathField); filePath != "" {
+		content.Metadata["file_path"] = filePath
+	}
+
+	return content, nil
+}
+
+// GetType returns the hook type name
+func (h *EventHandler) GetType() string {
+	return h.event.Name
+}
+
+// CanHandle returns true if this handler can process the given hook input
+func (h *EventHandler) CanHandle(input types.HookInput) bool {
+	return input.Framework == h.framework && input.HookType == h.event.Name
+}
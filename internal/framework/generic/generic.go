@@ -0,0 +1,229 @@
+package generic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const frameworkName = "generic"
+
+// profileSeparator separates the framework name from a profile name, as in "generic:windsurf"
+const profileSeparator = ":"
+
+// Framework implements the HookFramework interface for agents whose hook protocol is declared in configuration
+type Framework struct {
+	name     string
+	cfg      config.GenericConfig
+	handlers []framework.HookHandler
+}
+
+// Force compile-time check for interface implementation
+var (
+	_ framework.HookFramework      = (*Framework)(nil)
+	_ framework.AuditOnlyFramework = (*Framework)(nil)
+	_ framework.Detector           = (*Framework)(nil)
+)
+
+// NewFramework creates a new generic framework instance with one handler per configured event
+func NewFramework(cfg config.GenericConfig) *Framework {
+	return newFramework(frameworkName, cfg)
+}
+
+// NewProfileFramework creates the framework of a profile under generic.profiles, named "generic:<name>"
+// Fields the profile leaves unset are taken from the top-level generic configuration
+func NewProfileFramework(name string, cfg config.GenericConfig) (*Framework, error) {
+	profile, ok := cfg.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("generic profile %q not configured", name)
+	}
+	// Decision override keys separate the framework from the hook type with "."
+	if name == "" || strings.ContainsAny(name, "."+profileSeparator) {
+		return nil, fmt.Errorf("invalid generic profile name %q (must not be empty or contain '.' or ':')", name)
+	}
+
+	return newFramework(frameworkName+profileSeparator+name, inherit(profile, cfg)), nil
+}
+
+// newFramework creates a generic framework registered under name
+func newFramework(name string, cfg config.GenericConfig) *Framework {
+	f := &Framework{
+		name:     name,
+		cfg:      cfg,
+		handlers: []framework.HookHandler{},
+	}
+
+	for _, event := range cfg.Events {
+		f.RegisterHandler(NewEventHandler(name, event, cfg.SessionIDField))
+	}
+
+	return f
+}
+
+// inherit fills the fields a profile leaves unset from the top-level generic configuration
+// Exit codes are inherited as a whole when the profile sets none of them
+func inherit(profile, base config.GenericConfig) config.GenericConfig {
+	if profile.EventField == "" {
+		profile.EventField = base.EventField
+	}
+	if profile.SessionIDField == "" {
+		profile.SessionIDField = base.SessionIDField
+	}
+	if len(profile.Events) == 0 {
+		profile.Events = base.Events
+	}
+	if profile.Output.Allow == "" {
+		profile.Output.Allow = base.Output.Allow
+	}
+	if profile.Output.Block == "" {
+		profile.Output.Block = base.Output.Block
+	}
+	if profile.Output.Ask == "" {
+		profile.Output.Ask = base.Output.Ask
+	}
+	if profile.ExitCodes == (config.GenericExitCodes{}) {
+		profile.ExitCodes = base.ExitCodes
+	}
+	profile.Profiles = nil
+
+	return profile
+}
+
+// RegisterHandler registers a hook handler with the framework
+func (f *Framework) RegisterHandler(handler framework.HookHandler) {
+	f.handlers = append(f.handlers, handler)
+}
+
+// GetHandler returns the appropriate handler for the given input
+func (f *Framework) GetHandler(input types.HookInput) (framework.HookHandler, error) {
+	for _, handler := range f.handlers {
+		if handler.CanHandle(input) {
+			return handler, nil
+		}
+	}
+	return nil, fmt.Errorf("no handler found for hook type %q", input.HookType)
+}
+
+// ParseInput reads and parses hook data from stdin, selecting the event name with generic.event_field
+func (f *Framework) ParseInput(reader io.Reader) (types.HookInput, error) {
+	if len(f.cfg.Events) == 0 {
+		return types.HookInput{}, fmt.Errorf("%s framework has no events configured; set generic.events", f.name)
+	}
+
+	var rawData map[string]any
+
+	decoder := json.NewDecoder(reader)
+	if err := decoder.Decode(&rawData); err != nil {
+		return types.HookInput{}, fmt.Errorf("failed to decode JSON input; %w", err)
+	}
+
+	eventName := selectString(rawData, f.cfg.EventField)
+	if eventName == "" {
+		return types.HookInput{}, fmt.Errorf("missing or invalid event name at %q", f.cfg.EventField)
+	}
+
+	return types.HookInput{
+		Framework: f.name,
+		HookType:  eventName,
+		RawData:   rawData,
+	}, nil
+}
+
+// Detect returns true for payloads whose generic.event_field selects a configured event
+func (f *Framework) Detect(rawData map[string]any) bool {
+	eventName := selectString(rawData, f.cfg.EventField)
+	if eventName == "" {
+		return false
+	}
+
+	_, err := f.GetHandler(types.HookInput{Framework: f.name, HookType: eventName})
+	return err == nil
+}
+
+// outputData is the data available to output templates
+type outputData struct {
+	Event           string          // Event name
+	Action          string          // "allow", "ask" or "deny"
+	Block           bool            // Whether the action is blocked
+	Reason          string          // Human-readable explanation
+	RedactedContent string          // Scanned content with secrets masked (redact mode only)
+	Findings        []types.Finding // Findings that led to the decision
+}
+
+// FormatOutput renders the configured output template for the decision
+func (f *Framework) FormatOutput(decision types.Decision, input types.HookInput) ([]byte, error) {
+	text := f.cfg.Output.Allow
+	switch {
+	case decision.Action == types.ActionAsk && f.cfg.Output.Ask != "":
+		text = f.cfg.Output.Ask
+	case decision.Block || decision.Action == types.ActionAsk:
+		// Without an ask template, ask blocks rather than silently allowing
+		text = f.cfg.Output.Block
+	}
+
+	tmpl, err := template.New("output").Funcs(template.FuncMap{"json": toJSON}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse output template; %w", err)
+	}
+
+	findings, _ := decision.Metadata["findings"].([]types.Finding)
+	data := outputData{
+		Event:           input.HookType,
+		Action:          decision.Action,
+		Block:           decision.Block,
+		Reason:          decision.Reason,
+		RedactedContent: decision.RedactedContent,
+		Findings:        findings,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render output template; %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// toJSON marshals a template value as JSON, so strings are quoted and escaped
+func toJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// IsAuditOnly reports whether the configured event cannot be blocked by the agent
+func (f *Framework) IsAuditOnly(input types.HookInput) bool {
+	for _, event := range f.cfg.Events {
+		if event.Name == input.HookType {
+			return event.AuditOnly
+		}
+	}
+	return false
+}
+
+// GetExitCode returns the configured exit code for the decision
+// Ask decisions only use their own exit code when an ask output template is configured
+func (f *Framework) GetExitCode(decision types.Decision) int {
+	switch {
+	case decision.Action == types.ActionAsk && f.cfg.Output.Ask != "":
+		return f.cfg.ExitCodes.Ask
+	case decision.Block || decision.Action == types.ActionAsk:
+		return f.cfg.ExitCodes.Block
+	default:
+		return f.cfg.ExitCodes.Allow
+	}
+}
+
+// GetName returns the framework name, "generic:<profile>" for a profile
+func (f *Framework) GetName() string {
+	return f.name
+}
@@ -0,0 +1,57 @@
+package generic
+
+import (
+	"strconv"
+	"strings"
+)
+
+// selectPath returns the values found at a gjson-style dot path in a decoded JSON document
+// Path segments are object keys or array indexes; "#" selects every element of an array
+// Example: "tool_info.edits.#.new_string" selects new_string from each edit
+func selectPath(data any, path string) []any {
+	if path == "" {
+		return nil
+	}
+
+	values := []any{data}
+	for _, segment := range strings.Split(path, ".") {
+		var next []any
+		for _, value := range values {
+			next = append(next, selectSegment(value, segment)...)
+		}
+		if len(next) == 0 {
+			return nil
+		}
+		values = next
+	}
+
+	return values
+}
+
+// selectSegment applies a single path segment to a value
+func selectSegment(value any, segment string) []any {
+	switch v := value.(type) {
+	case map[string]any:
+		if child, ok := v[segment]; ok {
+			return []any{child}
+		}
+	case []any:
+		if segment == "#" {
+			return v
+		}
+		if index, err := strconv.Atoi(segment); err == nil && index >= 0 && index < len(v) {
+			return []any{v[index]}
+		}
+	}
+	return nil
+}
+
+// selectString returns the first string found at path, or an empty string
+func selectString(data any, path string) string {
+	for _, value := range selectPath(data, path) {
+		if s, ok := value.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
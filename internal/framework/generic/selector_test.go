@@ -0,0 +1,67 @@
+package generic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSelectPath(t *testing.T) {
+	data := map[string]any{
+		"agent_action_name": "pre_write_code",
+		"tool_info": map[string]any{
+			"file_path": "/project/.env",
+			"edits": []any{
+				map[string]any{"old_string": "a", "new_string": "first"},
+				map[string]any{"old_string": "b", "new_string": "second"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		expect []any
+	}{
+		{
+			name:   "top-level key",
+			path:   "agent_action_name",
+			expect: []any{"pre_write_code"},
+		},
+		{
+			name:   "nested key",
+			path:   "tool_info.file_path",
+			expect: []any{"/project/.env"},
+		},
+		{
+			name:   "array index",
+			path:   "tool_info.edits.1.new_string",
+			expect: []any{"second"},
+		},
+		{
+			name:   "every array element",
+			path:   "tool_info.edits.#.new_string",
+			expect: []any{"first", "second"},
+		},
+		{
+			name: "missing key",
+			path: "tool_info.command_line",
+		},
+		{
+			name: "index out of range",
+			path: "tool_info.edits.2",
+		},
+		{
+			name: "empty path",
+			path: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := selectPath(data, tt.path)
+			if !reflect.DeepEqual(result, tt.expect) {
+				t.Errorf("selectPath(%q) = %v, want %v", tt.path, result, tt.expect)
+			}
+		})
+	}
+}
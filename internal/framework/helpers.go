@@ -0,0 +1,55 @@
+package framework
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+// DecodeRawData converts raw hook JSON data into a hook-specific input struct
+func DecodeRawData(rawData map[string]any, v any) error {
+	// Marshal and unmarshal to convert map to struct
+	data, err := json.Marshal(rawData)
+	if err != nil {
+		return fmt.Errorf("failed to marshal input data; %w", err)
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// HasFindings reports whether a decision was made on findings rather than on a failed scan
+func HasFindings(decision types.Decision) bool {
+	_, ok := decision.Metadata["findings"]
+	return ok
+}
+
+// CollectStrings walks an arbitrary JSON value and returns every non-empty string it contains
+// Map keys are visited in sorted order so the output is deterministic
+func CollectStrings(value any) []string {
+	var result []string
+
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			result = append(result, v)
+		}
+	case []any:
+		for _, item := range v {
+			result = append(result, CollectStrings(item)...)
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			result = append(result, CollectStrings(v[key])...)
+		}
+	}
+
+	return result
+}
@@ -2,27 +2,36 @@ package framework
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 )
 
-var (
-	frameworks = make(map[string]HookFramework)
+// Registry manages the hook frameworks available to a processor
+type Registry struct {
+	frameworks map[string]HookFramework
 	mu         sync.RWMutex
-)
+}
+
+// NewRegistry creates a new framework registry
+func NewRegistry() *Registry {
+	return &Registry{
+		frameworks: make(map[string]HookFramework),
+	}
+}
 
 // RegisterFramework registers a hook framework implementation
-func RegisterFramework(name string, framework HookFramework) {
-	mu.Lock()
-	defer mu.Unlock()
-	frameworks[name] = framework
+func (r *Registry) RegisterFramework(name string, framework HookFramework) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.frameworks[name] = framework
 }
 
 // GetFramework retrieves a registered framework by name
-func GetFramework(name string) (HookFramework, error) {
-	mu.RLock()
-	defer mu.RUnlock()
+func (r *Registry) GetFramework(name string) (HookFramework, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-	framework, ok := frameworks[name]
+	framework, ok := r.frameworks[name]
 	if !ok {
 		return nil, fmt.Errorf("framework %q not registered", name)
 	}
@@ -30,15 +39,44 @@ func GetFramework(name string) (HookFramework, error) {
 	return framework, nil
 }
 
-// ListFrameworks returns a list of all registered framework names
-func ListFrameworks() []string {
-	mu.RLock()
-	defer mu.RUnlock()
+// ListFrameworks returns a sorted list of all registered framework names
+func (r *Registry) ListFrameworks() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-	names := make([]string, 0, len(frameworks))
-	for name := range frameworks {
-		names = append(names, name)
+	return r.sortedNames()
+}
+
+// DetectFramework returns the single registered framework that recognizes the decoded hook payload
+// It fails when no framework or more than one framework matches
+func (r *Registry) DetectFramework(rawData map[string]any) (HookFramework, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []string
+	for name, framework := range r.frameworks {
+		if detector, ok := framework.(Detector); ok && detector.Detect(rawData) {
+			matches = append(matches, name)
+		}
 	}
+	sort.Strings(matches)
 
+	switch len(matches) {
+	case 1:
+		return r.frameworks[matches[0]], nil
+	case 0:
+		return nil, fmt.Errorf("unable to detect framework from input; specify --framework (available frameworks: %v)", r.sortedNames())
+	default:
+		return nil, fmt.Errorf("input matches multiple frameworks %v; specify --framework (available frameworks: %v)", matches, r.sortedNames())
+	}
+}
+
+// sortedNames returns the registered framework names in sorted order; the caller must hold mu
+func (r *Registry) sortedNames() []string {
+	names := make([]string, 0, len(r.frameworks))
+	for name := range r.frameworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 	return names
 }
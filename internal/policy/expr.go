@@ -0,0 +1,533 @@
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// kind is the static type of an expression
+type kind int
+
+const (
+	kindString kind = iota
+	kindBool
+	kindList
+)
+
+func (k kind) String() string {
+	switch k {
+	case kindString:
+		return "string"
+	case kindBool:
+		return "bool"
+	default:
+		return "list"
+	}
+}
+
+// variables are the names an expression can refer to, with their types
+var variables = map[string]kind{
+	"finding.type":        kindString,
+	"finding.severity":    kindString,
+	"finding.location":    kindString,
+	"finding.description": kindString,
+	"finding.fingerprint": kindString,
+	"finding.engines":     kindList,
+	"hook_type":           kindString,
+	"tool_name":           kindString,
+	"cwd":                 kindString,
+	"framework":           kindString,
+}
+
+// lookup returns the value of a variable for an environment
+func (env Env) lookup(name string) any {
+	switch name {
+	case "finding.type":
+		return env.Finding.Type
+	case "finding.severity":
+		return env.Finding.Severity
+	case "finding.location":
+		return env.Finding.Location
+	case "finding.description":
+		return env.Finding.Description
+	case "finding.fingerprint":
+		return env.Finding.Fingerprint
+	case "finding.engines":
+		return env.Finding.Engines
+	case "hook_type":
+		return env.HookType
+	case "tool_name":
+		return env.ToolName
+	case "cwd":
+		return env.CWD
+	default:
+		return env.Framework
+	}
+}
+
+// node is a type-checked expression; evaluating it cannot fail
+type node interface {
+	kind() kind
+	eval(env Env) any
+}
+
+type literal struct {
+	value any
+	typ   kind
+}
+
+func (n literal) kind() kind     { return n.typ }
+func (n literal) eval(_ Env) any { return n.value }
+
+type variable struct {
+	name string
+}
+
+func (n variable) kind() kind       { return variables[n.name] }
+func (n variable) eval(env Env) any { return env.lookup(n.name) }
+
+type list struct {
+	items []node
+}
+
+func (n list) kind() kind { return kindList }
+
+func (n list) eval(env Env) any {
+	values := make([]string, len(n.items))
+	for i, item := range n.items {
+		values[i] = item.eval(env).(string)
+	}
+	return values
+}
+
+type not struct {
+	operand node
+}
+
+func (n not) kind() kind       { return kindBool }
+func (n not) eval(env Env) any { return !n.operand.eval(env).(bool) }
+
+type binary struct {
+	op          string
+	left, right node
+}
+
+func (n binary) kind() kind { return kindBool }
+
+func (n binary) eval(env Env) any {
+	switch n.op {
+	case "&&":
+		return n.left.eval(env).(bool) && n.right.eval(env).(bool)
+	case "||":
+		return n.left.eval(env).(bool) || n.right.eval(env).(bool)
+	case "==":
+		return n.left.eval(env) == n.right.eval(env)
+	case "!=":
+		return n.left.eval(env) != n.right.eval(env)
+	default: // "in"
+		value := n.left.eval(env).(string)
+		for _, item := range n.right.eval(env).([]string) {
+			if item == value {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// call is a string method: matches, glob, startsWith, endsWith or contains
+type call struct {
+	method   string
+	receiver node
+	arg      node
+	pattern  *regexp.Regexp // Compiled argument of matches and glob
+}
+
+func (n call) kind() kind { return kindBool }
+
+func (n call) eval(env Env) any {
+	value := n.receiver.eval(env).(string)
+	if n.pattern != nil {
+		return n.pattern.MatchString(value)
+	}
+
+	arg := n.arg.eval(env).(string)
+	switch n.method {
+	case "startsWith":
+		return strings.HasPrefix(value, arg)
+	case "endsWith":
+		return strings.HasSuffix(value, arg)
+	default: // "contains"
+		return strings.Contains(value, arg)
+	}
+}
+
+// methods lists the string methods an expression can call
+var methods = map[string]bool{
+	"matches":    true,
+	"glob":       true,
+	"startsWith": true,
+	"endsWith":   true,
+	"contains":   true,
+}
+
+// token is a lexical token; kind is the operator or punctuation itself for those tokens
+type token struct {
+	kind  string // "ident", "string", "eof" or the operator
+	text  string
+	value string // Unquoted value of a string token
+	pos   int
+}
+
+// compile parses and type-checks a boolean expression
+func compile(source string) (node, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != "eof" {
+		return nil, fmt.Errorf("unexpected %q at offset %d", tok.text, tok.pos)
+	}
+	if expr.kind() != kindBool {
+		return nil, fmt.Errorf("expression is a %s, not a bool", expr.kind())
+	}
+
+	return expr, nil
+}
+
+// tokenize splits an expression into tokens
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(source); {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"' || c == '\'':
+			end, value, err := scanString(source, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: "string", text: source[i:end], value: value, pos: i})
+			i = end
+		case c == '_' || unicode.IsLetter(rune(c)):
+			start := i
+			for i < len(source) && (source[i] == '_' || unicode.IsLetter(rune(source[i])) || unicode.IsDigit(rune(source[i]))) {
+				i++
+			}
+			tokens = append(tokens, token{kind: "ident", text: source[start:i], pos: start})
+		default:
+			op := ""
+			for _, candidate := range []string{"&&", "||", "==", "!=", "!", "(", ")", "[", "]", ",", "."} {
+				if strings.HasPrefix(source[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q at offset %d", c, i)
+			}
+			tokens = append(tokens, token{kind: op, text: op, pos: i})
+			i += len(op)
+		}
+	}
+
+	return append(tokens, token{kind: "eof", text: "end of expression", pos: len(source)}), nil
+}
+
+// scanString reads the string literal starting at start, returning the offset past it and its value
+// Both quote styles accept the escapes of Go string literals
+func scanString(source string, start int) (int, string, error) {
+	quote := source[start]
+	for i := start + 1; i < len(source); i++ {
+		switch source[i] {
+		case '\\':
+			i++
+		case quote:
+			body := source[start+1 : i]
+			if quote == '\'' {
+				body = strings.ReplaceAll(strings.ReplaceAll(body, `\'`, `'`), `"`, `\"`)
+			}
+			value, err := strconv.Unquote(`"` + body + `"`)
+			if err != nil {
+				return 0, "", fmt.Errorf("invalid string at offset %d", start)
+			}
+			return i + 1, value, nil
+		}
+	}
+	return 0, "", fmt.Errorf("unterminated string at offset %d", start)
+}
+
+// parser is a recursive descent parser; precedence from lowest: ||, &&, !, comparisons, method calls
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != "eof" {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) expect(kind string) (token, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return tok, fmt.Errorf("expected %q but found %q at offset %d", kind, tok.text, tok.pos)
+	}
+	return tok, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	return p.parseLogical("||", p.parseAnd)
+}
+
+func (p *parser) parseAnd() (node, error) {
+	return p.parseLogical("&&", p.parseUnary)
+}
+
+// parseLogical parses a left-associative chain of a boolean operator
+func (p *parser) parseLogical(op string, operand func() (node, error)) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == op {
+		tok := p.next()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if left.kind() != kindBool || right.kind() != kindBool {
+			return nil, fmt.Errorf("%s at offset %d needs bool operands", op, tok.pos)
+		}
+		left = binary{op: op, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.peek().kind != "!" {
+		return p.parseComparison()
+	}
+
+	tok := p.next()
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if operand.kind() != kindBool {
+		return nil, fmt.Errorf("! at offset %d needs a bool operand", tok.pos)
+	}
+	return not{operand: operand}, nil
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	if tok.kind != "==" && tok.kind != "!=" && !(tok.kind == "ident" && tok.text == "in") {
+		return left, nil
+	}
+	p.next()
+
+	right, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok.text == "in" {
+		if left.kind() != kindString || right.kind() != kindList {
+			return nil, fmt.Errorf("in at offset %d needs a string and a list", tok.pos)
+		}
+		return binary{op: "in", left: left, right: right}, nil
+	}
+
+	if left.kind() != right.kind() || left.kind() == kindList {
+		return nil, fmt.Errorf("cannot compare %s and %s at offset %d", left.kind(), right.kind(), tok.pos)
+	}
+	return binary{op: tok.kind, left: left, right: right}, nil
+}
+
+// parsePostfix parses an operand followed by method calls; dotted names are variables until a call
+func (p *parser) parsePostfix() (node, error) {
+	var operand node
+	var name string
+
+	tok := p.peek()
+	if tok.kind == "ident" && tok.text != "true" && tok.text != "false" {
+		p.next()
+		name = tok.text
+	} else {
+		var err error
+		if operand, err = p.parsePrimary(); err != nil {
+			return nil, err
+		}
+	}
+
+	for p.peek().kind == "." {
+		p.next()
+		member, err := p.expect("ident")
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek().kind != "(" {
+			if operand != nil {
+				return nil, fmt.Errorf("unexpected %q at offset %d", member.text, member.pos)
+			}
+			name += "." + member.text
+			continue
+		}
+
+		receiver := operand
+		if receiver == nil {
+			if receiver, err = resolve(name, tok.pos); err != nil {
+				return nil, err
+			}
+		}
+		if operand, err = p.parseCall(receiver, member); err != nil {
+			return nil, err
+		}
+	}
+
+	if operand == nil {
+		return resolve(name, tok.pos)
+	}
+	return operand, nil
+}
+
+// parseCall parses the argument of a method call on receiver
+func (p *parser) parseCall(receiver node, method token) (node, error) {
+	if !methods[method.text] {
+		return nil, fmt.Errorf("unknown method %q at offset %d", method.text, method.pos)
+	}
+	if receiver.kind() != kindString {
+		return nil, fmt.Errorf("%s at offset %d needs a string receiver", method.text, method.pos)
+	}
+
+	p.next() // "("
+	arg, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	if arg.kind() != kindString {
+		return nil, fmt.Errorf("%s at offset %d needs a string argument", method.text, method.pos)
+	}
+
+	c := call{method: method.text, receiver: receiver, arg: arg}
+	if method.text == "matches" || method.text == "glob" {
+		pattern, ok := arg.(literal)
+		if !ok {
+			return nil, fmt.Errorf("%s at offset %d needs a string literal pattern", method.text, method.pos)
+		}
+
+		source := pattern.value.(string)
+		if method.text == "glob" {
+			source = globToRegexp(source)
+		}
+		if c.pattern, err = regexp.Compile(source); err != nil {
+			return nil, fmt.Errorf("invalid %s pattern at offset %d; %w", method.text, method.pos, err)
+		}
+	}
+
+	return c, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case "string":
+		return literal{value: tok.value, typ: kindString}, nil
+	case "ident":
+		return literal{value: tok.text == "true", typ: kindBool}, nil
+	case "(":
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.expect(")")
+		return expr, err
+	case "[":
+		return p.parseList()
+	default:
+		return nil, fmt.Errorf("unexpected %q at offset %d", tok.text, tok.pos)
+	}
+}
+
+// parseList parses the items of a list literal after its "["
+func (p *parser) parseList() (node, error) {
+	var items []node
+
+	for p.peek().kind != "]" {
+		item, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if item.kind() != kindString {
+			return nil, fmt.Errorf("list items must be strings")
+		}
+		items = append(items, item)
+
+		if p.peek().kind != "," {
+			break
+		}
+		p.next()
+	}
+
+	if _, err := p.expect("]"); err != nil {
+		return nil, err
+	}
+	return list{items: items}, nil
+}
+
+// resolve returns the variable for a name, or an error for unknown names
+func resolve(name string, pos int) (node, error) {
+	if _, ok := variables[name]; !ok {
+		return nil, fmt.Errorf("unknown variable %q at offset %d", name, pos)
+	}
+	return variable{name: name}, nil
+}
+
+// globToRegexp converts a glob to an anchored regular expression
+// Unlike allowlist paths, "*" also matches "/", so "*/sandbox/*" matches any directory below a sandbox
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
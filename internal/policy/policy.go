@@ -0,0 +1,108 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+// DefaultRule is the rule name recorded for findings that take the default action
+const DefaultRule = "default"
+
+// Env holds the values a rule expression is evaluated against
+type Env struct {
+	Finding   types.Finding
+	HookType  string
+	ToolName  string
+	CWD       string
+	Framework string
+}
+
+// Rule is a compiled policy rule
+type Rule struct {
+	Name   string
+	Action string // "allow", "ask" or "deny"
+	expr   node
+}
+
+// Policy holds the ordered rules deciding the action for each finding
+type Policy struct {
+	rules []Rule
+}
+
+// New compiles the configured rules
+// Invalid rules are skipped and reported in the returned error; the valid ones are still used
+func New(rules []config.PolicyRule) (*Policy, error) {
+	var errs []error
+	policy := &Policy{}
+
+	for i, rule := range rules {
+		compiled, err := compileRule(rule)
+		if err != nil {
+			name := rule.Name
+			if name == "" {
+				name = fmt.Sprintf("%d", i+1)
+			}
+			errs = append(errs, fmt.Errorf("policy rule %s: %w", name, err))
+			continue
+		}
+		policy.rules = append(policy.rules, compiled)
+	}
+
+	return policy, errors.Join(errs...)
+}
+
+// Len returns the number of rules
+func (p *Policy) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.rules)
+}
+
+// Match returns the first rule whose expression is true for the environment
+func (p *Policy) Match(env Env) (Rule, bool) {
+	if p == nil {
+		return Rule{}, false
+	}
+
+	for _, rule := range p.rules {
+		if rule.expr.eval(env).(bool) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// compileRule validates a rule and compiles its expression
+func compileRule(rule config.PolicyRule) (Rule, error) {
+	compiled := Rule{
+		Name:   rule.Name,
+		Action: strings.ToLower(rule.Action),
+	}
+
+	if compiled.Name == "" {
+		return compiled, fmt.Errorf("no name set")
+	}
+
+	switch compiled.Action {
+	case types.ActionAllow, types.ActionAsk, types.ActionDeny:
+	default:
+		return compiled, fmt.Errorf("invalid action %q (expected allow, ask or deny)", rule.Action)
+	}
+
+	if strings.TrimSpace(rule.When) == "" {
+		return compiled, fmt.Errorf("no when expression set")
+	}
+
+	expr, err := compile(rule.When)
+	if err != nil {
+		return compiled, fmt.Errorf("invalid when expression; %w", err)
+	}
+	compiled.expr = expr
+
+	return compiled, nil
+}
@@ -0,0 +1,139 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+func TestCompile(t *testing.T) {
+	env := Env{
+		Finding: types.Finding{
+			Type:     "aws_access_key_id",
+			Severity: "high",
+			Location: "src/config.go",
+			Engines:  []string{"builtin", "gitleaks"},
+		},
+		HookType:  "PreToolUse",
+		ToolName:  "Write",
+		CWD:       "/home/dev/projects/sandbox/demo",
+		Framework: "claude",
+	}
+
+	tests := []struct {
+		name   string
+		expr   string
+		expect bool
+	}{
+		{name: "equality", expr: `finding.severity == "high"`, expect: true},
+		{name: "inequality", expr: `framework != "claude"`, expect: false},
+		{name: "glob", expr: `finding.type.glob("aws_*")`, expect: true},
+		{name: "glob spans directories", expr: `cwd.glob("*/sandbox/*")`, expect: true},
+		{name: "glob is anchored", expr: `cwd.glob("sandbox/*")`, expect: false},
+		{name: "regular expression", expr: `finding.location.matches("\\.go$")`, expect: true},
+		{name: "single quotes", expr: `tool_name == 'Write'`, expect: true},
+		{name: "string methods", expr: `cwd.startsWith("/home") && cwd.endsWith("demo") && cwd.contains("projects")`, expect: true},
+		{name: "in list literal", expr: `hook_type in ["PreToolUse", "PostToolUse"]`, expect: true},
+		{name: "in list variable", expr: `"trufflehog" in finding.engines`, expect: false},
+		{name: "negation and grouping", expr: `!(finding.severity == "low" || framework == "cursor")`, expect: true},
+		{name: "and binds tighter than or", expr: `true || false && false`, expect: true},
+		{name: "bool literal", expr: `false`, expect: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := compile(tt.expr)
+			if err != nil {
+				t.Fatalf("compile(%q) failed: %v", tt.expr, err)
+			}
+			if got := expr.eval(env).(bool); got != tt.expect {
+				t.Errorf("eval(%q) = %v, want %v", tt.expr, got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		expr        string
+		expectError string
+	}{
+		{name: "unknown variable", expr: `finding.secret == "x"`, expectError: `unknown variable "finding.secret"`},
+		{name: "unknown method", expr: `cwd.lower()`, expectError: `unknown method "lower"`},
+		{name: "not a bool", expr: `cwd`, expectError: "not a bool"},
+		{name: "type mismatch", expr: `cwd == true`, expectError: "cannot compare string and bool"},
+		{name: "in needs a list", expr: `cwd in tool_name`, expectError: "needs a string and a list"},
+		{name: "dynamic pattern", expr: `cwd.matches(tool_name)`, expectError: "string literal pattern"},
+		{name: "invalid regular expression", expr: `cwd.matches("(")`, expectError: "invalid matches pattern"},
+		{name: "unterminated string", expr: `cwd == "x`, expectError: "unterminated string"},
+		{name: "trailing tokens", expr: `true true`, expectError: `unexpected "true"`},
+		{name: "missing parenthesis", expr: `(true`, expectError: `expected ")"`},
+		{name: "unexpected character", expr: `cwd = "x"`, expectError: "unexpected '='"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compile(tt.expr)
+			if err == nil {
+				t.Fatalf("compile(%q) succeeded, want error containing %q", tt.expr, tt.expectError)
+			}
+			if !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("compile(%q) error = %q, want it to contain %q", tt.expr, err, tt.expectError)
+			}
+		})
+	}
+}
+
+func TestPolicy_Match(t *testing.T) {
+	policy, err := New([]config.PolicyRule{
+		{Name: "block-aws", When: `finding.type.glob("aws_*")`, Action: "deny"},
+		{Name: "sandbox-passwords", When: `finding.type == "generic_password" && cwd.glob("*/sandbox/*")`, Action: "ASK"},
+		{Name: "broken", When: `finding.type ==`, Action: "deny"},
+		{Name: "no-action", When: `true`},
+	})
+	if err == nil || !strings.Contains(err.Error(), "policy rule broken") || !strings.Contains(err.Error(), "policy rule no-action") {
+		t.Errorf("New() error = %v, want the broken and no-action rules reported", err)
+	}
+	if policy.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2 valid rules", policy.Len())
+	}
+
+	tests := []struct {
+		name         string
+		env          Env
+		expectRule   string
+		expectAction string
+	}{
+		{
+			name:         "aws key anywhere",
+			env:          Env{Finding: types.Finding{Type: "aws_secret_access_key"}, CWD: "/srv/sandbox/app"},
+			expectRule:   "block-aws",
+			expectAction: types.ActionDeny,
+		},
+		{
+			name:         "password in sandbox",
+			env:          Env{Finding: types.Finding{Type: "generic_password"}, CWD: "/home/dev/sandbox/app"},
+			expectRule:   "sandbox-passwords",
+			expectAction: types.ActionAsk,
+		},
+		{
+			name: "password elsewhere",
+			env:  Env{Finding: types.Finding{Type: "generic_password"}, CWD: "/home/dev/prod"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := policy.Match(tt.env)
+			if ok != (tt.expectRule != "") {
+				t.Fatalf("Match() matched = %v, want %v", ok, tt.expectRule != "")
+			}
+			if rule.Name != tt.expectRule || rule.Action != tt.expectAction {
+				t.Errorf("Match() = %s/%s, want %s/%s", rule.Name, rule.Action, tt.expectRule, tt.expectAction)
+			}
+		})
+	}
+}
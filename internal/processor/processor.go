@@ -3,17 +3,28 @@ package processor
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
 	"github.com/leefowlercu/agent-hook-vault-radar/internal/decision"
 	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework"
 	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework/claude"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework/codex"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework/copilot"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework/cursor"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework/gemini"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/framework/generic"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/project"
 	"github.com/leefowlercu/agent-hook-vault-radar/internal/remediation"
 	"github.com/leefowlercu/agent-hook-vault-radar/internal/remediation/strategies"
 	"github.com/leefowlercu/agent-hook-vault-radar/internal/scanner"
@@ -27,6 +38,19 @@ type Processor struct {
 	scanner           scanner.Scanner
 	decisionEngine    *decision.Engine
 	remediationEngine *remediation.Engine
+	frameworks        *framework.Registry // Built from this processor's configuration only
+	logFile           io.Closer           // Log file opened by Load, closed by Close
+}
+
+// ExitError reports that the framework asked for a non-zero process exit code
+// The decision reason has already been written to stderr when it is returned
+type ExitError struct {
+	Code int
+}
+
+// Error returns a description of the exit code
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("hook exited with code %d", e.Code)
 }
 
 // NewProcessor creates a new processor instance
@@ -42,9 +66,10 @@ func NewProcessor(cfg *config.Config, logger *slog.Logger) *Processor {
 	return &Processor{
 		cfg:               cfg,
 		logger:            logger,
-		scanner:           scanner.NewVaultRadarScanner(cfg, logger),
-		decisionEngine:    decision.NewEngine(cfg),
+		scanner:           scanner.NewScanner(cfg, logger),
+		decisionEngine:    newDecisionEngine(cfg, logger),
 		remediationEngine: remediationEngine,
+		frameworks:        newFrameworkRegistry(cfg, logger),
 	}
 }
 
@@ -70,38 +95,62 @@ func registerRemediationStrategies(engine *remediation.Engine, cfg *config.Confi
 	}
 }
 
-// Process is the main entry point that reads from stdin and writes to stdout
-func Process(stdin io.Reader, stdout io.Writer, frameworkName string) error {
+// Load creates a processor from the current configuration, logging to the configured log file
+func Load() (*Processor, error) {
 	// Load configuration
 	cfg, err := config.GetConfig()
 	if err != nil {
-		return fmt.Errorf("failed to load configuration; %w", err)
+		return nil, fmt.Errorf("failed to load configuration; %w", err)
 	}
 
 	// Setup logger
-	logger := setupLogger(cfg)
+	logger, logFile := setupLogger(cfg)
 
 	// Create processor
 	proc := NewProcessor(cfg, logger)
+	proc.logFile = logFile
 
-	// Process the hook
-	ctx := context.Background()
-	return proc.ProcessHook(ctx, stdin, stdout, frameworkName)
+	return proc, nil
 }
 
-// ProcessHook processes a single hook invocation
-func (p *Processor) ProcessHook(ctx context.Context, stdin io.Reader, stdout io.Writer, frameworkName string) error {
-	p.logger.Info("processing hook request", "framework", frameworkName)
+// Close releases the log file opened by Load
+func (p *Processor) Close() error {
+	if p.logFile == nil {
+		return nil
+	}
+	return p.logFile.Close()
+}
+
+// Config returns the configuration the processor was built from
+func (p *Processor) Config() *config.Config {
+	return p.cfg
+}
 
-	// Register frameworks
-	framework.RegisterFramework("claude", claude.NewFramework())
+// Logger returns the processor's logger
+func (p *Processor) Logger() *slog.Logger {
+	return p.logger
+}
 
-	// Get the specified framework
-	fw, err := framework.GetFramework(frameworkName)
+// Process is the main entry point that reads from stdin and writes to stdout
+// A non-zero framework exit code is returned as an *ExitError
+func Process(stdin io.Reader, stdout, stderr io.Writer, frameworkName string) error {
+	proc, err := Load()
 	if err != nil {
-		available := framework.ListFrameworks()
-		return fmt.Errorf("failed to get framework %q; available frameworks: %v", frameworkName, available)
+		return err
 	}
+	defer proc.Close()
+
+	// Process the hook; an interrupt cancels the scan so staged content is still wiped
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return proc.ProcessHook(ctx, stdin, stdout, stderr, frameworkName)
+}
+
+// ProcessHook processes a single hook invocation
+// The decision reason is written to stderr and an *ExitError returned when the framework asks for a non-zero exit code
+func (p *Processor) ProcessHook(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, frameworkName string) error {
+	p.logger.Info("processing hook request", "framework", frameworkName)
 
 	// Read stdin into buffer so we can still parse it
 	rawInput, err := io.ReadAll(stdin)
@@ -110,6 +159,14 @@ func (p *Processor) ProcessHook(ctx context.Context, stdin io.Reader, stdout io.
 		return fmt.Errorf("failed to read stdin; %w", err)
 	}
 
+	// Get the specified framework, or detect it from the payload when none was given
+	fw, err := p.selectFramework(frameworkName, rawInput)
+	if err != nil {
+		p.logger.Error("failed to select framework", "error", err)
+		return err
+	}
+	frameworkName = fw.GetName()
+
 	// Parse input from the buffer
 	hookInput, err := fw.ParseInput(bytes.NewReader(rawInput))
 	if err != nil {
@@ -121,19 +178,11 @@ func (p *Processor) ProcessHook(ctx context.Context, stdin io.Reader, stdout io.
 		"framework", hookInput.Framework,
 		"hook_type", hookInput.HookType)
 
-	// Get the appropriate handler - use a type-safe approach
-	var handler framework.HookHandler
-
-	// Type switch for framework-specific handling
-	switch f := fw.(type) {
-	case *claude.Framework:
-		handler, err = f.GetHandler(hookInput)
-		if err != nil {
-			p.logger.Error("failed to get handler", "error", err)
-			return fmt.Errorf("failed to get handler; %w", err)
-		}
-	default:
-		return fmt.Errorf("unsupported framework type: %T", fw)
+	// Get the handler for the hook type
+	handler, err := fw.GetHandler(hookInput)
+	if err != nil {
+		p.logger.Error("failed to get handler", "error", err)
+		return fmt.Errorf("failed to get handler; %w", err)
 	}
 
 	p.logger.Debug("using handler", "type", handler.GetType())
@@ -147,12 +196,29 @@ func (p *Processor) ProcessHook(ctx context.Context, stdin io.Reader, stdout io.
 
 	p.logger.Debug("extracted content",
 		"type", content.Type,
-		"length", len(content.Content))
-
-	// Scan content
-	scanResults, err := p.scanner.Scan(ctx, content)
+		"length", len(content.Content),
+		"parts", len(content.Parts))
+
+	// Layer the project's configuration over the user configuration for this invocation
+	hookScanner, decisionEngine := p.forProject(projectDir(hookInput, content))
+
+	// Scan content (nothing to scan, e.g. a tool call the handler does not inspect, is allowed as-is)
+	scanResults := types.ScanResults{Findings: []types.Finding{}}
+	switch {
+	case len(content.Parts) > 0:
+		scanResults, err = scanner.ScanParts(ctx, hookScanner, content.Parts)
+	case content.Content != "":
+		scanResults, err = hookScanner.Scan(ctx, content)
+	default:
+		p.logger.Debug("no content to scan, skipping scanner")
+	}
 	if err != nil {
-		p.logger.Error("scan failed", "error", err)
+		var scanErr *types.ScanError
+		kind := types.ScanErrorExecution
+		if errors.As(err, &scanErr) {
+			kind = scanErr.Kind
+		}
+		p.logger.Error("scan failed", "error", err, "kind", kind)
 		// Continue with error in results
 	}
 
@@ -161,15 +227,43 @@ func (p *Processor) ProcessHook(ctx context.Context, stdin io.Reader, stdout io.
 		"finding_count", len(scanResults.Findings),
 		"duration", scanResults.ScanDuration)
 
+	// Handlers that extract incrementally only move past content that was scanned
+	if committer, ok := handler.(framework.ContentCommitter); ok && err == nil && scanResults.Error == nil {
+		if err := committer.CommitContent(hookInput, content); err != nil {
+			p.logger.Warn("failed to commit extracted content", "error", err)
+		}
+	}
+
+	// Fingerprint findings and mark allowlisted ones, so remediation records them as suppressed
+	scanResults = decisionEngine.ApplyAllowlist(content, scanResults)
+
 	// Make decision using the decision engine (framework-agnostic)
-	finalDecision, err := p.decisionEngine.Evaluate(ctx, scanResults)
+	finalDecision, err := decisionEngine.Evaluate(ctx, hookInput, content, scanResults)
 	if err != nil {
 		p.logger.Error("failed to make decision", "error", err)
 		return fmt.Errorf("failed to make decision; %w", err)
 	}
 
+	// Frameworks that cannot block still run remediation, which records that the decision was not enforced
+	if af, ok := fw.(framework.AuditOnlyFramework); ok && af.IsAuditOnly(hookInput) {
+		finalDecision.AuditOnly = true
+	}
+
+	if suppressed, ok := finalDecision.Metadata["suppressed_count"]; ok {
+		p.logger.Info("findings suppressed by allowlist", "count", suppressed)
+	}
+
 	p.logger.Info("decision made",
-		"block", finalDecision.Block)
+		"block", finalDecision.Block,
+		"action", finalDecision.Action,
+		"audit_only", finalDecision.AuditOnly)
+
+	if overrides, ok := finalDecision.Metadata["decision_overrides"]; ok {
+		p.logger.Debug("decision overrides applied", "overrides", overrides)
+	}
+	if rule, ok := finalDecision.Metadata["policy_rule"]; ok {
+		p.logger.Info("decision set by policy rule", "rule", rule)
+	}
 
 	// Execute remediation if enabled
 	remediationInput := types.RemediationInput{
@@ -215,15 +309,110 @@ func (p *Processor) ProcessHook(ctx context.Context, stdin io.Reader, stdout io.
 	// Get exit code from framework (framework determines exit code semantics)
 	exitCode := fw.GetExitCode(finalDecision)
 	if exitCode != 0 {
-		os.Exit(exitCode)
+		// Frameworks that block through the exit code read the reason from stderr
+		fmt.Fprintln(stderr, strings.TrimSpace(finalDecision.Reason))
+		return &ExitError{Code: exitCode}
 	}
 
 	return nil
 }
 
+// forProject returns the scanner and decision engine for a hook running in dir
+// They are the processor's own unless a project file applies, in which case they are built from the merged configuration
+func (p *Processor) forProject(dir string) (scanner.Scanner, *decision.Engine) {
+	cfg, result, err := project.Resolve(p.cfg, dir)
+	if err != nil {
+		p.logger.Warn("ignoring project config", "error", err)
+		return p.scanner, p.decisionEngine
+	}
+	if result.Path == "" {
+		return p.scanner, p.decisionEngine
+	}
+
+	p.logger.Info("applied project config", "path", result.Path)
+	if len(result.Ignored) > 0 {
+		p.logger.Warn("ignored project config settings", "path", result.Path, "settings", result.Ignored)
+	}
+
+	return scanner.NewScanner(cfg, p.logger), newDecisionEngine(cfg, p.logger)
+}
+
+// newDecisionEngine creates a decision engine, warning about configuration it had to skip or replace
+func newDecisionEngine(cfg *config.Config, logger *slog.Logger) *decision.Engine {
+	engine := decision.NewEngine(cfg)
+	if err := engine.AllowlistError(); err != nil {
+		logger.Warn("skipping invalid allowlist entries", "error", err)
+	}
+	if err := engine.PolicyError(); err != nil {
+		logger.Warn("skipping invalid policy rules or decision overrides", "error", err)
+	}
+	if err := engine.SettingsError(); err != nil {
+		logger.Warn("replacing invalid decision settings", "error", err)
+	}
+
+	return engine
+}
+
+// projectDir returns the working directory of a hook, from the handler's metadata or the raw payload
+func projectDir(input types.HookInput, content types.ScanContent) string {
+	if cwd := content.Metadata["cwd"]; cwd != "" {
+		return cwd
+	}
+	cwd, _ := input.RawData["cwd"].(string)
+	return cwd
+}
+
+// newFrameworkRegistry registers every supported hook framework and the generic profiles of the configuration
+func newFrameworkRegistry(cfg *config.Config, logger *slog.Logger) *framework.Registry {
+	registry := framework.NewRegistry()
+	registry.RegisterFramework("claude", claude.NewFramework())
+	registry.RegisterFramework("cursor", cursor.NewFramework())
+	registry.RegisterFramework("gemini", gemini.NewFramework())
+	registry.RegisterFramework("codex", codex.NewFramework())
+	registry.RegisterFramework("copilot", copilot.NewFramework())
+	registry.RegisterFramework("generic", generic.NewFramework(cfg.Generic))
+
+	for name := range cfg.Generic.Profiles {
+		fw, err := generic.NewProfileFramework(name, cfg.Generic)
+		if err != nil {
+			logger.Warn("skipping generic profile", "error", err)
+			continue
+		}
+		registry.RegisterFramework(fw.GetName(), fw)
+	}
+
+	return registry
+}
+
+// selectFramework returns the named framework, or detects one from the raw hook payload when name is empty
+func (p *Processor) selectFramework(name string, rawInput []byte) (framework.HookFramework, error) {
+	if name != "" {
+		fw, err := p.frameworks.GetFramework(name)
+		if err != nil {
+			available := p.frameworks.ListFrameworks()
+			return nil, fmt.Errorf("failed to get framework %q; available frameworks: %v", name, available)
+		}
+		return fw, nil
+	}
+
+	var rawData map[string]any
+	if err := json.Unmarshal(rawInput, &rawData); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON input for framework detection; %w", err)
+	}
+
+	fw, err := p.frameworks.DetectFramework(rawData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect framework; %w", err)
+	}
+
+	p.logger.Info("detected framework from input", "framework", fw.GetName())
+	return fw, nil
+}
+
 // setupLogger creates and configures the logger based on configuration
 // Logs are written to file only (not stderr) to avoid interfering with hook framework IO
-func setupLogger(cfg *config.Config) *slog.Logger {
+// The opened log file, if any, is returned so it can be closed
+func setupLogger(cfg *config.Config) (*slog.Logger, io.Closer) {
 	var level slog.Level
 	switch cfg.Logging.Level {
 	case "debug":
@@ -244,6 +433,7 @@ func setupLogger(cfg *config.Config) *slog.Logger {
 
 	// Determine output writer - file only, no stderr
 	var output io.Writer
+	var closer io.Closer
 
 	if cfg.Logging.LogFile != "" {
 		logFile, err := openLogFile(cfg.Logging.LogFile)
@@ -253,6 +443,7 @@ func setupLogger(cfg *config.Config) *slog.Logger {
 			output = io.Discard
 		} else {
 			output = logFile
+			closer = logFile
 		}
 	} else {
 		// No log file configured - disable logging
@@ -266,18 +457,14 @@ func setupLogger(cfg *config.Config) *slog.Logger {
 		handler = slog.NewTextHandler(output, opts)
 	}
 
-	return slog.New(handler)
+	return slog.New(handler), closer
 }
 
 // openLogFile opens or creates a log file for writing
 func openLogFile(path string) (*os.File, error) {
-	// Expand ~ to home directory if present
-	if len(path) > 0 && path[0] == '~' {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get home directory; %w", err)
-		}
-		path = filepath.Join(home, path[1:])
+	path, err := config.ExpandHome(path)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create parent directory if it doesn't exist
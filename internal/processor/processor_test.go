@@ -0,0 +1,99 @@
+package processor
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+)
+
+func TestProcessor_SelectFramework_Detect(t *testing.T) {
+	tests := []struct {
+		name      string
+		fixture   string
+		input     string
+		framework string
+		wantErr   bool
+	}{
+		{name: "claude prompt", fixture: "claude/userpromptsubmit.json", framework: "claude"},
+		{name: "claude pre tool use", fixture: "claude/pretooluse_bash.json", framework: "claude"},
+		{name: "claude stop", fixture: "claude/stop.json", framework: "claude"},
+		{name: "cursor prompt", fixture: "cursor/beforesubmitprompt.json", framework: "cursor"},
+		{name: "cursor shell", fixture: "cursor/beforeshellexecution.json", framework: "cursor"},
+		{name: "gemini agent", fixture: "gemini/beforeagent.json", framework: "gemini"},
+		{name: "gemini tool", fixture: "gemini/beforetool_shell.json", framework: "gemini"},
+		{name: "codex notify", fixture: "codex/agent_turn_complete.json", framework: "codex"},
+		{name: "copilot cli tool", fixture: "copilot/pretooluse_bash.json", framework: "copilot"},
+		{name: "copilot cli prompt", fixture: "copilot/userpromptsubmitted.json", framework: "copilot"},
+		{name: "copilot vscode", fixture: "copilot/vscode_pretooluse.json", framework: "copilot"},
+		{name: "unknown payload", input: `{"event":"something"}`, wantErr: true},
+		{name: "invalid JSON", input: `not json`, wantErr: true},
+	}
+
+	p := NewProcessor(&config.DefaultConfig, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawInput := []byte(tt.input)
+			if tt.fixture != "" {
+				data, err := os.ReadFile(filepath.Join("..", "..", "testdata", tt.fixture))
+				if err != nil {
+					t.Fatalf("failed to read fixture: %v", err)
+				}
+				rawInput = data
+			}
+
+			fw, err := p.selectFramework("", rawInput)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("selectFramework() = %q, want error", fw.GetName())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectFramework() failed: %v", err)
+			}
+			if fw.GetName() != tt.framework {
+				t.Errorf("selectFramework() = %q, want %q", fw.GetName(), tt.framework)
+			}
+		})
+	}
+}
+
+func TestProcessor_SelectFramework_Explicit(t *testing.T) {
+	p := NewProcessor(&config.DefaultConfig, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// An explicit framework wins even when the payload belongs to another one
+	fw, err := p.selectFramework("cursor", []byte(`{"hook_event_name":"UserPromptSubmit","session_id":"abc"}`))
+	if err != nil {
+		t.Fatalf("selectFramework() failed: %v", err)
+	}
+	if fw.GetName() != "cursor" {
+		t.Errorf("selectFramework() = %q, want %q", fw.GetName(), "cursor")
+	}
+
+	if _, err := p.selectFramework("unknown", nil); err == nil {
+		t.Error("selectFramework() with unknown framework should fail")
+	}
+}
+
+func TestProcessor_SelectFramework_Profiles(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	cfg := config.DefaultConfig
+	cfg.Generic.Profiles = map[string]config.GenericConfig{"acme": {EventField: "event"}}
+	p := NewProcessor(&cfg, logger)
+
+	if _, err := p.selectFramework("generic:acme", nil); err != nil {
+		t.Fatalf("selectFramework() failed: %v", err)
+	}
+
+	// A processor built from a configuration without the profile, as after a daemon reload, must not see it
+	reloaded := NewProcessor(&config.DefaultConfig, logger)
+	if fw, err := reloaded.selectFramework("generic:acme", nil); err == nil {
+		t.Errorf("selectFramework() = %q, want error for a removed profile", fw.GetName())
+	}
+}
@@ -0,0 +1,321 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/decision"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+	"github.com/spf13/viper"
+)
+
+// FileName is the project configuration file looked up from the hook's working directory
+const FileName = ".vault-radar-hook.yaml"
+
+// settable lists the keys a project file may set; other keys are ignored
+var settable = []string{
+	"decision.block_on_findings",
+	"decision.severity_threshold",
+	"decision.severity_actions",
+	"decision.rules",
+	"decision.default_action",
+	"decision.overrides",
+	"allowlist.entries",
+	"allowlist.baseline_path",
+	"vault_radar.extra_args",
+	"gitleaks.extra_args",
+	"trufflehog.extra_args",
+}
+
+// Overlay is the part of the configuration a project file can change
+type Overlay struct {
+	Decision   DecisionOverlay        `mapstructure:"decision"`
+	Allowlist  config.AllowlistConfig `mapstructure:"allowlist"`
+	VaultRadar ScannerOverlay         `mapstructure:"vault_radar"`
+	Gitleaks   ScannerOverlay         `mapstructure:"gitleaks"`
+	Trufflehog ScannerOverlay         `mapstructure:"trufflehog"`
+}
+
+// DecisionOverlay holds the decision settings of a project; unset fields keep the user configuration
+type DecisionOverlay struct {
+	BlockOnFindings   *bool                     `mapstructure:"block_on_findings"`
+	SeverityThreshold string                    `mapstructure:"severity_threshold"`
+	SeverityActions   map[string]string         `mapstructure:"severity_actions"`
+	Rules             []config.PolicyRule       `mapstructure:"rules"` // Evaluated before the user's rules
+	DefaultAction     string                    `mapstructure:"default_action"`
+	Overrides         []config.DecisionOverride `mapstructure:"overrides"` // Applied after the user's overrides
+}
+
+// ScannerOverlay holds scanner arguments added by a project
+type ScannerOverlay struct {
+	ExtraArgs []string `mapstructure:"extra_args"` // Appended to the user's extra arguments
+}
+
+// Result describes the project configuration applied to a hook invocation
+type Result struct {
+	Path    string   // Project file that was applied, empty when none was found
+	Ignored []string // Settings that were not applied, with the reason
+}
+
+// Resolve layers the project file found from dir over the user configuration
+// The user configuration is returned unchanged when project files are disabled or none is found
+func Resolve(cfg *config.Config, dir string) (*config.Config, Result, error) {
+	if !cfg.Project.Enabled {
+		return cfg, Result{}, nil
+	}
+
+	path, ok := Find(dir)
+	if !ok {
+		return cfg, Result{}, nil
+	}
+
+	overlay, ignored, err := Load(path)
+	if err != nil {
+		return cfg, Result{}, err
+	}
+
+	merged, rejected, err := Apply(cfg, overlay, filepath.Dir(path))
+	if err != nil {
+		return cfg, Result{}, err
+	}
+
+	return merged, Result{Path: path, Ignored: append(ignored, rejected...)}, nil
+}
+
+// Find returns the project file for a working directory
+// Directories are searched from dir up to the repository root (the nearest directory containing .git);
+// outside a repository only dir itself is searched
+func Find(dir string) (string, bool) {
+	if dir == "" || !filepath.IsAbs(dir) {
+		return "", false
+	}
+	dir = filepath.Clean(dir)
+	root := repositoryRoot(dir)
+
+	for {
+		path := filepath.Join(dir, FileName)
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			return path, true
+		}
+
+		parent := filepath.Dir(dir)
+		if root == "" || dir == root || parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// repositoryRoot returns the nearest directory at or above dir that contains .git, or empty
+func repositoryRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Load reads a project file, returning the keys it sets that projects cannot change
+func Load(path string) (Overlay, []string, error) {
+	var overlay Overlay
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return overlay, nil, fmt.Errorf("failed to read project config; %w", err)
+	}
+
+	var ignored []string
+	for _, key := range v.AllKeys() {
+		if !isSettable(key) {
+			ignored = append(ignored, key+" (not settable by projects)")
+		}
+	}
+	sort.Strings(ignored)
+
+	if err := v.Unmarshal(&overlay); err != nil {
+		return overlay, nil, fmt.Errorf("failed to parse project config %s; %w", path, err)
+	}
+
+	return overlay, ignored, nil
+}
+
+// isSettable reports whether a flattened key is one of the settable keys or below one
+func isSettable(key string) bool {
+	for _, allowed := range settable {
+		if key == allowed || strings.HasPrefix(key, allowed+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply returns a copy of the user configuration with the overlay merged in
+// Unless project.allow_loosening is set, settings that loosen the policy are left out and returned as rejected
+// A relative allowlist baseline path is resolved against dir, the directory of the project file
+func Apply(cfg *config.Config, overlay Overlay, dir string) (*config.Config, []string, error) {
+	merged := *cfg
+	m := &merger{allow: cfg.Project.AllowLoosening}
+
+	m.mergeDecision(&merged.Decision, overlay.Decision)
+
+	if err := m.mergeAllowlist(&merged.Allowlist, overlay.Allowlist, dir); err != nil {
+		return cfg, nil, err
+	}
+
+	// Extra arguments can change what a scanner reports, so they count as loosening
+	merged.VaultRadar.ExtraArgs = m.mergeArgs("vault_radar.extra_args", merged.VaultRadar.ExtraArgs, overlay.VaultRadar.ExtraArgs)
+	merged.Gitleaks.ExtraArgs = m.mergeArgs("gitleaks.extra_args", merged.Gitleaks.ExtraArgs, overlay.Gitleaks.ExtraArgs)
+	merged.Trufflehog.ExtraArgs = m.mergeArgs("trufflehog.extra_args", merged.Trufflehog.ExtraArgs, overlay.Trufflehog.ExtraArgs)
+
+	return &merged, m.rejected, nil
+}
+
+// merger merges an overlay, collecting the settings rejected for loosening the policy
+type merger struct {
+	allow    bool
+	rejected []string
+}
+
+// accept reports whether a setting may be applied, recording it as rejected otherwise
+func (m *merger) accept(key string, loosens bool) bool {
+	if loosens && !m.allow {
+		m.rejected = append(m.rejected, key+" (loosens policy)")
+		return false
+	}
+	return true
+}
+
+// mergeDecision merges the decision settings; slices and maps are copied so the user configuration is not modified
+func (m *merger) mergeDecision(d *config.DecisionConfig, overlay DecisionOverlay) {
+	user := *d
+
+	if v := overlay.BlockOnFindings; v != nil && m.accept("decision.block_on_findings", d.BlockOnFindings && !*v) {
+		d.BlockOnFindings = *v
+	}
+
+	if v := overlay.SeverityThreshold; v != "" &&
+		m.accept("decision.severity_threshold", decision.SeverityLevel(v) > decision.SeverityLevel(d.SeverityThreshold)) {
+		d.SeverityThreshold = v
+	}
+
+	if len(overlay.SeverityActions) > 0 {
+		actions := make(map[string]string, len(d.SeverityActions)+len(overlay.SeverityActions))
+		for severity, action := range d.SeverityActions {
+			actions[severity] = action
+		}
+
+		severities := make([]string, 0, len(overlay.SeverityActions))
+		for severity := range overlay.SeverityActions {
+			severities = append(severities, severity)
+		}
+		sort.Strings(severities)
+
+		for _, severity := range severities {
+			action := overlay.SeverityActions[severity]
+			// Unmapped severities deny
+			loosens := decision.ActionLevel(decision.EffectiveAction(action)) < decision.ActionLevel(decision.EffectiveAction(actions[severity]))
+			if m.accept("decision.severity_actions."+severity, loosens) {
+				actions[severity] = action
+			}
+		}
+		d.SeverityActions = actions
+	}
+
+	if len(overlay.Rules) > 0 {
+		var rules []config.PolicyRule
+		for _, rule := range overlay.Rules {
+			if m.accept("decision.rules."+rule.Name, decision.EffectiveAction(rule.Action) != types.ActionDeny) {
+				rules = append(rules, rule)
+			}
+		}
+		d.Rules = append(rules, d.Rules...)
+	}
+
+	if v := overlay.DefaultAction; v != "" {
+		// Without a default action, findings below the threshold are allowed, so only deny tightens
+		loosens := decision.ActionLevel(decision.EffectiveAction(v)) < decision.ActionLevel(decision.EffectiveAction(d.DefaultAction))
+		if m.accept("decision.default_action", loosens) {
+			d.DefaultAction = v
+		}
+	}
+
+	if len(overlay.Overrides) > 0 {
+		overrides := append([]config.DecisionOverride{}, d.Overrides...)
+		for _, o := range overlay.Overrides {
+			// A project override can win over a less specific user override, so it is compared with
+			// every user setting that can apply to the same hooks
+			threshold, block := userSettings(user, o.Match)
+			loosens := (o.BlockOnFindings != nil && !*o.BlockOnFindings && block) ||
+				len(o.IncludeTypes) > 0 || len(o.ExcludeTypes) > 0 ||
+				(o.SeverityThreshold != "" && decision.SeverityLevel(o.SeverityThreshold) > decision.SeverityLevel(threshold))
+			if m.accept("decision.overrides."+o.Match, loosens) {
+				overrides = append(overrides, o)
+			}
+		}
+		d.Overrides = overrides
+	}
+}
+
+// userSettings returns the strictest severity threshold and block_on_findings the user configuration
+// applies to any hook an override key matches, from the top-level settings and the overlapping user overrides
+func userSettings(d config.DecisionConfig, match string) (string, bool) {
+	threshold, block := d.SeverityThreshold, d.BlockOnFindings
+
+	for _, o := range d.Overrides {
+		if !decision.Overlaps(o.Match, match) {
+			continue
+		}
+		if o.SeverityThreshold != "" && decision.SeverityLevel(o.SeverityThreshold) < decision.SeverityLevel(threshold) {
+			threshold = o.SeverityThreshold
+		}
+		if o.BlockOnFindings != nil && *o.BlockOnFindings {
+			block = true
+		}
+	}
+
+	return threshold, block
+}
+
+// mergeAllowlist adds the project's allowlist entries and the entries of its baseline file
+func (m *merger) mergeAllowlist(a *config.AllowlistConfig, overlay config.AllowlistConfig, dir string) error {
+	if len(overlay.Entries) > 0 && m.accept("allowlist.entries", true) {
+		a.Entries = append(a.Entries[:len(a.Entries):len(a.Entries)], overlay.Entries...)
+	}
+
+	if overlay.BaselinePath == "" || !m.accept("allowlist.baseline_path", true) {
+		return nil
+	}
+
+	path := overlay.BaselinePath
+	if !filepath.IsAbs(path) && !strings.HasPrefix(path, "~") {
+		path = filepath.Join(dir, path)
+	}
+
+	entries, err := config.LoadBaseline(path)
+	if err != nil {
+		return err
+	}
+	a.Entries = append(a.Entries[:len(a.Entries):len(a.Entries)], entries...)
+
+	return nil
+}
+
+// mergeArgs appends the project's extra arguments for a scanner
+func (m *merger) mergeArgs(key string, args, extra []string) []string {
+	if len(extra) == 0 || !m.accept(key, true) {
+		return args
+	}
+	return append(args[:len(args):len(args)], extra...)
+}
@@ -0,0 +1,273 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+)
+
+// writeFile creates a file and its parent directories
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+
+	// A repository with a project file at its root and a nested package without one
+	repo := filepath.Join(root, "repo")
+	os.MkdirAll(filepath.Join(repo, ".git"), 0755)
+	writeFile(t, filepath.Join(repo, FileName), "decision: {}\n")
+	os.MkdirAll(filepath.Join(repo, "pkg", "nested"), 0755)
+
+	// A monorepo package with its own project file
+	writeFile(t, filepath.Join(repo, "services", "api", FileName), "decision: {}\n")
+	os.MkdirAll(filepath.Join(repo, "services", "api", "cmd"), 0755)
+
+	// A repository below a directory with a project file, which must not be used
+	writeFile(t, filepath.Join(root, "outer", FileName), "decision: {}\n")
+	os.MkdirAll(filepath.Join(root, "outer", "inner", ".git"), 0755)
+	os.MkdirAll(filepath.Join(root, "outer", "inner", "src"), 0755)
+
+	// A directory outside any repository
+	os.MkdirAll(filepath.Join(root, "plain", "sub"), 0755)
+	writeFile(t, filepath.Join(root, "plain", FileName), "decision: {}\n")
+
+	tests := []struct {
+		name       string
+		dir        string
+		expectPath string
+	}{
+		{name: "repository root", dir: repo, expectPath: filepath.Join(repo, FileName)},
+		{name: "walks up to the repository root", dir: filepath.Join(repo, "pkg", "nested"), expectPath: filepath.Join(repo, FileName)},
+		{name: "nearest file wins", dir: filepath.Join(repo, "services", "api", "cmd"), expectPath: filepath.Join(repo, "services", "api", FileName)},
+		{name: "stops at the repository root", dir: filepath.Join(root, "outer", "inner", "src")},
+		{name: "outside a repository only dir is searched", dir: filepath.Join(root, "plain", "sub")},
+		{name: "outside a repository in dir", dir: filepath.Join(root, "plain"), expectPath: filepath.Join(root, "plain", FileName)},
+		{name: "relative directory", dir: "repo"},
+		{name: "no directory", dir: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, ok := Find(tt.dir)
+			if ok != (tt.expectPath != "") || path != tt.expectPath {
+				t.Errorf("Find(%q) = %q, %v, want %q", tt.dir, path, ok, tt.expectPath)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	repo := t.TempDir()
+	os.MkdirAll(filepath.Join(repo, ".git"), 0755)
+	writeFile(t, filepath.Join(repo, FileName), `
+decision:
+  block_on_findings: false
+  severity_threshold: low
+  severity_actions:
+    high: ask
+    info: deny
+  rules:
+    - name: block-aws
+      when: 'finding.type.glob("aws_*")'
+      action: deny
+    - name: allow-fixtures
+      when: 'finding.location.glob("*/testdata/*")'
+      action: allow
+  overrides:
+    - match: claude.PostToolUse
+      severity_threshold: critical
+allowlist:
+  baseline_path: baseline.yaml
+  entries:
+    - pattern: "EXAMPLE$"
+gitleaks:
+  extra_args: ["--no-banner"]
+  command: /tmp/evil
+logging:
+  level: debug
+`)
+	writeFile(t, filepath.Join(repo, "baseline.yaml"), `
+entries:
+  - fingerprint: "sha256:899810ad2dec47f41150754033dab118b99eaae4d1ef88bd1a7bdcf53dac518f"
+`)
+
+	user := func(allowLoosening bool) *config.Config {
+		return &config.Config{
+			Gitleaks: config.GitleaksConfig{Command: "gitleaks", ExtraArgs: []string{"--redact"}},
+			Decision: config.DecisionConfig{
+				BlockOnFindings:   true,
+				SeverityThreshold: "medium",
+				SeverityActions:   map[string]string{"info": "ask"},
+				Rules:             []config.PolicyRule{{Name: "user-rule", When: "true", Action: "ask"}},
+			},
+			Project: config.ProjectConfig{Enabled: true, AllowLoosening: allowLoosening},
+		}
+	}
+
+	t.Run("tightening only", func(t *testing.T) {
+		cfg := user(false)
+		merged, result, err := Resolve(cfg, filepath.Join(repo, "src"))
+		if err != nil {
+			t.Fatalf("Resolve() failed: %v", err)
+		}
+		if result.Path != filepath.Join(repo, FileName) {
+			t.Errorf("Path = %q, want the project file", result.Path)
+		}
+
+		if !merged.Decision.BlockOnFindings {
+			t.Error("block_on_findings loosened to false")
+		}
+		if merged.Decision.SeverityThreshold != "low" {
+			t.Errorf("severity_threshold = %q, want the stricter %q", merged.Decision.SeverityThreshold, "low")
+		}
+		if merged.Decision.SeverityActions["info"] != "deny" || merged.Decision.SeverityActions["high"] != "" {
+			t.Errorf("severity_actions = %v, want info tightened to deny and high not loosened", merged.Decision.SeverityActions)
+		}
+		if len(merged.Decision.Rules) != 2 || merged.Decision.Rules[0].Name != "block-aws" || merged.Decision.Rules[1].Name != "user-rule" {
+			t.Errorf("rules = %+v, want the project deny rule before the user rule", merged.Decision.Rules)
+		}
+		if len(merged.Decision.Overrides) != 0 || len(merged.Allowlist.Entries) != 0 {
+			t.Errorf("loosening overrides or allowlist entries applied: %+v, %+v", merged.Decision.Overrides, merged.Allowlist.Entries)
+		}
+		if !slices.Equal(merged.Gitleaks.ExtraArgs, []string{"--redact"}) || merged.Gitleaks.Command != "gitleaks" {
+			t.Errorf("gitleaks = %+v, want the user's command and arguments", merged.Gitleaks)
+		}
+
+		for _, want := range []string{
+			"decision.block_on_findings (loosens policy)",
+			"decision.severity_actions.high (loosens policy)",
+			"decision.rules.allow-fixtures (loosens policy)",
+			"decision.overrides.claude.PostToolUse (loosens policy)",
+			"allowlist.entries (loosens policy)",
+			"allowlist.baseline_path (loosens policy)",
+			"gitleaks.extra_args (loosens policy)",
+			"gitleaks.command (not settable by projects)",
+			"logging.level (not settable by projects)",
+		} {
+			if !slices.Contains(result.Ignored, want) {
+				t.Errorf("Ignored = %v, want it to contain %q", result.Ignored, want)
+			}
+		}
+
+		// The user configuration is shared by every invocation and must not change
+		if cfg.Decision.SeverityActions["info"] != "ask" || len(cfg.Decision.Rules) != 1 || cfg.Decision.SeverityThreshold != "medium" {
+			t.Errorf("user configuration modified: %+v", cfg.Decision)
+		}
+	})
+
+	t.Run("loosening allowed", func(t *testing.T) {
+		merged, result, err := Resolve(user(true), repo)
+		if err != nil {
+			t.Fatalf("Resolve() failed: %v", err)
+		}
+
+		if merged.Decision.BlockOnFindings {
+			t.Error("block_on_findings not applied")
+		}
+		if merged.Decision.SeverityActions["high"] != "ask" {
+			t.Errorf("severity_actions = %v, want high set to ask", merged.Decision.SeverityActions)
+		}
+		if len(merged.Decision.Rules) != 3 || len(merged.Decision.Overrides) != 1 {
+			t.Errorf("rules = %d, overrides = %d, want 3 and 1", len(merged.Decision.Rules), len(merged.Decision.Overrides))
+		}
+		if len(merged.Allowlist.Entries) != 2 || !strings.HasPrefix(merged.Allowlist.Entries[1].Fingerprint, "sha256:") {
+			t.Errorf("allowlist entries = %+v, want the project entry and the project baseline", merged.Allowlist.Entries)
+		}
+		if !slices.Equal(merged.Gitleaks.ExtraArgs, []string{"--redact", "--no-banner"}) {
+			t.Errorf("gitleaks extra_args = %v, want the project arguments appended", merged.Gitleaks.ExtraArgs)
+		}
+		for _, ignored := range result.Ignored {
+			if strings.Contains(ignored, "loosens policy") {
+				t.Errorf("setting rejected although loosening is allowed: %s", ignored)
+			}
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		cfg := user(true)
+		cfg.Project.Enabled = false
+
+		merged, result, err := Resolve(cfg, repo)
+		if err != nil {
+			t.Fatalf("Resolve() failed: %v", err)
+		}
+		if merged != cfg || result.Path != "" {
+			t.Errorf("Resolve() applied %q although project files are disabled", result.Path)
+		}
+	})
+}
+
+func TestApply_Overrides(t *testing.T) {
+	boolPtr := func(v bool) *bool { return &v }
+
+	user := &config.Config{
+		Decision: config.DecisionConfig{
+			BlockOnFindings:   true,
+			SeverityThreshold: "high",
+			Overrides: []config.DecisionOverride{
+				{Match: "claude.UserPromptSubmit", SeverityThreshold: "low"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		override config.DecisionOverride
+		expectOK bool
+	}{
+		{
+			name:     "looser than a user override for the same hooks",
+			override: config.DecisionOverride{Match: "claude.UserPromptSubmit", SeverityThreshold: "high"},
+		},
+		{
+			name:     "more specific than a wildcard user override",
+			override: config.DecisionOverride{Match: "claude.*.Bash", SeverityThreshold: "medium"},
+		},
+		{
+			name:     "wildcard overlapping a user override",
+			override: config.DecisionOverride{Match: "*.userpromptsubmit", SeverityThreshold: "critical"},
+		},
+		{
+			name:     "as strict as the user override",
+			override: config.DecisionOverride{Match: "claude.UserPromptSubmit", SeverityThreshold: "low"},
+			expectOK: true,
+		},
+		{
+			name:     "as strict as the top-level threshold where no user override applies",
+			override: config.DecisionOverride{Match: "claude.PreToolUse", SeverityThreshold: "high"},
+			expectOK: true,
+		},
+		{
+			name:     "disables blocking",
+			override: config.DecisionOverride{Match: "gemini", BlockOnFindings: boolPtr(false)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overlay := Overlay{Decision: DecisionOverlay{Overrides: []config.DecisionOverride{tt.override}}}
+
+			merged, rejected, err := Apply(user, overlay, t.TempDir())
+			if err != nil {
+				t.Fatalf("Apply() failed: %v", err)
+			}
+
+			applied := len(merged.Decision.Overrides) == len(user.Decision.Overrides)+1
+			if applied != tt.expectOK || (len(rejected) == 0) != tt.expectOK {
+				t.Errorf("Apply() applied = %v, rejected = %v, want applied %v", applied, rejected, tt.expectOK)
+			}
+		})
+	}
+}
@@ -30,8 +30,8 @@ func (p *Protocol) ShouldExecute(input types.RemediationInput) bool {
 		return false
 	}
 
-	// Check on_findings trigger
-	if p.Triggers.OnFindings && !input.ScanResults.HasFindings {
+	// Check on_findings trigger; findings suppressed by the allowlist count so that they are still recorded
+	if p.Triggers.OnFindings && !input.ScanResults.HasFindings && len(input.ScanResults.Findings) == 0 {
 		return false
 	}
 
@@ -142,6 +142,9 @@ func (s *LogStrategy) Execute(ctx context.Context, input types.RemediationInput)
 	} else {
 		message = fmt.Sprintf("Logged %d findings to %s", findingCount, filepath.Base(logPath))
 	}
+	if input.Decision.AuditOnly {
+		message += " (audit only; " + input.Framework + " cannot block)"
+	}
 
 	return types.RemediationResult{
 		StrategyType: s.GetType(),
@@ -198,11 +201,22 @@ func (s *LogStrategy) formatJSON(input types.RemediationInput) (string, error) {
 		"timestamp":     input.Timestamp.Format(time.RFC3339),
 		"framework":     input.Framework,
 		"session_id":    sessionID,
-		"blocked":       input.Decision.Block,
+		"blocked":       input.Decision.Block && !input.Decision.AuditOnly,
 		"finding_count": len(input.ScanResults.Findings),
 		"findings":      input.ScanResults.Findings,
 	}
 
+	// Allowlisted findings are listed with "suppressed": true; count them for quick filtering
+	if suppressed := suppressedCount(input.ScanResults.Findings); suppressed > 0 {
+		logEntry["suppressed_count"] = suppressed
+	}
+
+	// Audit-only frameworks cannot block, so record the action that was not enforced
+	if input.Decision.AuditOnly {
+		logEntry["audit_only"] = true
+		logEntry["unenforced_action"] = input.Decision.Action
+	}
+
 	// Marshal to JSON
 	data, err := json.Marshal(logEntry)
 	if err != nil {
@@ -226,13 +240,22 @@ func (s *LogStrategy) formatText(input types.RemediationInput) (string, error) {
 	timestamp := input.Timestamp.Format("2006-01-02 15:04:05")
 	findingCount := len(input.ScanResults.Findings)
 	blocked := "false"
-	if input.Decision.Block {
+	if input.Decision.Block && !input.Decision.AuditOnly {
 		blocked = "true"
 	}
 
 	sb.WriteString(fmt.Sprintf("[%s] Framework: %s | Session: %s | Findings: %d | Blocked: %s",
 		timestamp, input.Framework, sessionID, findingCount, blocked))
 
+	if suppressed := suppressedCount(input.ScanResults.Findings); suppressed > 0 {
+		sb.WriteString(fmt.Sprintf(" | Suppressed: %d", suppressed))
+	}
+
+	// Audit-only frameworks cannot block, so record the action that was not enforced
+	if input.Decision.AuditOnly {
+		sb.WriteString(fmt.Sprintf(" | Audit only (%s not enforced)", input.Decision.Action))
+	}
+
 	// Add findings details
 	for _, finding := range input.ScanResults.Findings {
 		sb.WriteString("\n  - [")
@@ -250,7 +273,38 @@ func (s *LogStrategy) formatText(input types.RemediationInput) (string, error) {
 			sb.WriteString(finding.Location)
 			sb.WriteString(")")
 		}
+
+		if len(finding.Engines) > 0 {
+			sb.WriteString(" via ")
+			sb.WriteString(strings.Join(finding.Engines, ", "))
+		}
+
+		if finding.Fingerprint != "" {
+			sb.WriteString(" [")
+			sb.WriteString(finding.Fingerprint)
+			sb.WriteString("]")
+		}
+
+		// Allowlisted findings are recorded with the reason they are considered safe
+		if finding.Suppressed {
+			sb.WriteString(" suppressed")
+			if finding.Justification != "" {
+				sb.WriteString(": ")
+				sb.WriteString(finding.Justification)
+			}
+		}
 	}
 
 	return sb.String(), nil
 }
+
+// suppressedCount returns the number of findings suppressed by the allowlist
+func suppressedCount(findings []types.Finding) int {
+	count := 0
+	for _, finding := range findings {
+		if finding.Suppressed {
+			count++
+		}
+	}
+	return count
+}
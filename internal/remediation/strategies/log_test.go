@@ -515,6 +515,18 @@ func TestLogStrategy_FormatJSON(t *testing.T) {
 	if entry["finding_count"] != float64(2) {
 		t.Errorf("finding_count = %v, want 2", entry["finding_count"])
 	}
+
+	// Existing consumers of findings.log read the original finding keys
+	findings, _ := entry["findings"].([]any)
+	if len(findings) == 0 {
+		t.Fatal("findings missing from JSON entry")
+	}
+	finding, _ := findings[0].(map[string]any)
+	for _, key := range []string{"Severity", "Type", "Location", "Description"} {
+		if _, ok := finding[key]; !ok {
+			t.Errorf("finding = %v, want key %q", finding, key)
+		}
+	}
 }
 
 func TestLogStrategy_FormatText(t *testing.T) {
@@ -541,3 +553,80 @@ func TestLogStrategy_FormatText(t *testing.T) {
 		t.Error("missing first finding in text format")
 	}
 }
+
+func TestLogStrategy_AuditOnly(t *testing.T) {
+	input := createTestInput()
+	input.Framework = "codex"
+	input.Decision.Action = types.ActionDeny
+	input.Decision.AuditOnly = true
+
+	jsonStrategy := &LogStrategy{logFile: "/tmp/test.log", format: "json"}
+	content, err := jsonStrategy.formatJSON(input)
+	if err != nil {
+		t.Fatalf("formatJSON() failed: %v", err)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(content), &entry); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if entry["blocked"] != false {
+		t.Errorf("blocked = %v, want false", entry["blocked"])
+	}
+	if entry["audit_only"] != true {
+		t.Errorf("audit_only = %v, want true", entry["audit_only"])
+	}
+	if entry["unenforced_action"] != types.ActionDeny {
+		t.Errorf("unenforced_action = %v, want %q", entry["unenforced_action"], types.ActionDeny)
+	}
+
+	textStrategy := &LogStrategy{logFile: "/tmp/test.log", format: "text"}
+	content, err = textStrategy.formatText(input)
+	if err != nil {
+		t.Fatalf("formatText() failed: %v", err)
+	}
+	if !strings.Contains(content, "Blocked: false | Audit only (deny not enforced)") {
+		t.Errorf("missing audit-only marker in text format: %s", content)
+	}
+}
+
+func TestLogStrategy_Suppressed(t *testing.T) {
+	input := createTestInput()
+	input.ScanResults.Findings[0].Fingerprint = "sha256:abc123"
+	input.ScanResults.Findings[0].Suppressed = true
+	input.ScanResults.Findings[0].Justification = "documentation key"
+
+	jsonStrategy := &LogStrategy{logFile: "/tmp/test.log", format: "json"}
+	content, err := jsonStrategy.formatJSON(input)
+	if err != nil {
+		t.Fatalf("formatJSON() failed: %v", err)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(content), &entry); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if entry["suppressed_count"] != float64(1) {
+		t.Errorf("suppressed_count = %v, want 1", entry["suppressed_count"])
+	}
+	findings := entry["findings"].([]any)
+	first := findings[0].(map[string]any)
+	if first["suppressed"] != true || first["justification"] != "documentation key" || first["fingerprint"] != "sha256:abc123" {
+		t.Errorf("first finding = %v, want it marked suppressed with justification and fingerprint", first)
+	}
+	if _, ok := findings[1].(map[string]any)["suppressed"]; ok {
+		t.Error("second finding should not be marked suppressed")
+	}
+
+	textStrategy := &LogStrategy{logFile: "/tmp/test.log", format: "text"}
+	content, err = textStrategy.formatText(input)
+	if err != nil {
+		t.Fatalf("formatText() failed: %v", err)
+	}
+	if !strings.Contains(content, "| Suppressed: 1") {
+		t.Errorf("missing suppressed count in text format: %s", content)
+	}
+	if !strings.Contains(content, "[sha256:abc123] suppressed: documentation key") {
+		t.Errorf("missing suppressed marker in text format: %s", content)
+	}
+}
@@ -0,0 +1,101 @@
+package scanner
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const builtinScannerName = "builtin"
+
+// BuiltinScanner implements the Scanner interface with a native regex and entropy rule pack
+// It needs no external binary, so it works where vault-radar is not installed or licensed
+type BuiltinScanner struct {
+	rules  []rule
+	logger *slog.Logger
+}
+
+// NewBuiltinScanner creates a new builtin scanner instance
+func NewBuiltinScanner(logger *slog.Logger) *BuiltinScanner {
+	return &BuiltinScanner{
+		rules:  builtinRules,
+		logger: logger,
+	}
+}
+
+// Scan matches the rule pack against the provided content
+func (s *BuiltinScanner) Scan(ctx context.Context, content types.ScanContent) (types.ScanResults, error) {
+	startTime := time.Now()
+
+	results := types.ScanResults{
+		HasFindings: false,
+		Findings:    []types.Finding{},
+	}
+
+	location := contentLocation(content)
+	for _, r := range s.rules {
+		if err := ctx.Err(); err != nil {
+			results.Error = &types.ScanError{Kind: types.ScanErrorTimeout, Err: err}
+			return results, results.Error
+		}
+
+		for _, match := range r.pattern.FindAllStringSubmatchIndex(content.Content, -1) {
+			start, end := match[2*r.group], match[2*r.group+1]
+			if start < 0 || end <= start {
+				continue
+			}
+
+			secret := content.Content[start:end]
+			if r.minEntropy > 0 && shannonEntropy(secret) < r.minEntropy {
+				continue
+			}
+
+			if overlapsAny(results.Findings, start, end) {
+				continue
+			}
+
+			finding := types.Finding{
+				Severity:    r.severity,
+				Type:        r.id,
+				Location:    location,
+				Description: r.description,
+			}
+			applyPosition(&finding, rawPosition{StartOffset: start, EndOffset: end}, content.Content)
+			results.Findings = append(results.Findings, finding)
+		}
+	}
+
+	// Report findings in the order they appear in the content
+	sort.SliceStable(results.Findings, func(i, j int) bool {
+		return results.Findings[i].StartOffset < results.Findings[j].StartOffset
+	})
+
+	results.HasFindings = len(results.Findings) > 0
+	results.ScanDuration = time.Since(startTime)
+
+	s.logger.Info("scan completed",
+		"scanner", builtinScannerName,
+		"has_findings", results.HasFindings,
+		"finding_count", len(results.Findings),
+		"duration", results.ScanDuration)
+
+	return results, nil
+}
+
+// overlapsAny reports whether the byte range [start, end) overlaps the span of any finding
+func overlapsAny(findings []types.Finding, start, end int) bool {
+	for _, finding := range findings {
+		if finding.HasSpan() && start < finding.EndOffset && finding.StartOffset < end {
+			return true
+		}
+	}
+	return false
+}
+
+// GetName returns the scanner name
+func (s *BuiltinScanner) GetName() string {
+	return builtinScannerName
+}
@@ -0,0 +1,257 @@
+package scanner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+// cacheVersion is part of every cache key; bump it when the builtin rules or the finding format change
+const cacheVersion = 1
+
+// pruneMarker is the file whose modification time records the last sweep of expired entries
+const pruneMarker = ".pruned"
+
+// cacheEntry is the on-disk form of a cached scan result
+// Only findings are stored (types, severities and positions); the scanned content is never written
+type cacheEntry struct {
+	CreatedAt time.Time       `json:"created_at"`
+	Findings  []types.Finding `json:"findings"`
+}
+
+// CachedScanner implements the Scanner interface by reusing results for content scanned before
+// Entries are keyed by the SHA-256 of the content and a fingerprint of the scanner configuration and rules file
+type CachedScanner struct {
+	scanner     Scanner
+	dir         string
+	ttl         time.Duration
+	fingerprint string
+	rulesPath   string // Scanner rules file whose contents are part of the key, read on every scan so edits apply at once
+	logger      *slog.Logger
+}
+
+// NewCachedScanner wraps a scanner with the result cache configured under cache
+func NewCachedScanner(scanner Scanner, cfg *config.Config, logger *slog.Logger) *CachedScanner {
+	return &CachedScanner{
+		scanner:     scanner,
+		dir:         cfg.Cache.Dir,
+		ttl:         time.Duration(cfg.Cache.TTLSeconds) * time.Second,
+		fingerprint: scannerFingerprint(cfg),
+		rulesPath:   cfg.Gitleaks.ConfigPath,
+		logger:      logger,
+	}
+}
+
+// Scan returns the cached result for the content, or scans it and caches the result
+// Failed scans are never cached, and cache errors only cost a rescan
+func (s *CachedScanner) Scan(ctx context.Context, content types.ScanContent) (types.ScanResults, error) {
+	startTime := time.Now()
+	key := s.cacheKey(content)
+
+	dir, err := cacheDir(s.dir)
+	if err != nil {
+		s.logger.Warn("scan cache unavailable", "error", err)
+		return s.scanner.Scan(ctx, content)
+	}
+	path := filepath.Join(dir, key+".json")
+
+	if entry, ok := s.load(path); ok {
+		s.logger.Info("scan cache hit",
+			"scanner", s.scanner.GetName(),
+			"key", key[:12],
+			"finding_count", len(entry.Findings),
+			"age", time.Since(entry.CreatedAt))
+
+		// Findings are stored without their location so identical content keeps its current origin
+		findings := entry.Findings
+		location := contentLocation(content)
+		for i := range findings {
+			findings[i].Location = location
+		}
+
+		return types.ScanResults{
+			HasFindings:  len(findings) > 0,
+			Findings:     findings,
+			ScanDuration: time.Since(startTime),
+			CacheHits:    1,
+		}, nil
+	}
+
+	results, err := s.scanner.Scan(ctx, content)
+	if err != nil || results.Error != nil {
+		return results, err
+	}
+
+	s.logger.Debug("scan cache miss", "scanner", s.scanner.GetName(), "key", key[:12])
+	s.store(dir, path, results.Findings)
+
+	return results, nil
+}
+
+// GetName returns the name of the wrapped scanner
+func (s *CachedScanner) GetName() string {
+	return s.scanner.GetName()
+}
+
+// cacheKey hashes everything that determines a scan result
+// The file name is included because Vault Radar applies file-type-aware rules
+func (s *CachedScanner) cacheKey(content types.ScanContent) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%s\x00%s\x00", s.fingerprint, rulesFingerprint(s.rulesPath), scanFileName(content))
+	hash.Write([]byte(content.Content))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// load reads a cache entry, discarding it when it is expired or unreadable
+func (s *CachedScanner) load(path string) (cacheEntry, bool) {
+	var entry cacheEntry
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entry, false
+	}
+
+	if err := json.Unmarshal(data, &entry); err != nil || time.Since(entry.CreatedAt) > s.ttl {
+		os.Remove(path)
+		return entry, false
+	}
+
+	return entry, true
+}
+
+// store writes a cache entry atomically and occasionally prunes expired entries
+func (s *CachedScanner) store(dir, path string, findings []types.Finding) {
+	stored := make([]types.Finding, len(findings))
+	for i, finding := range findings {
+		finding.Location = ""
+		stored[i] = finding
+	}
+
+	data, err := json.Marshal(cacheEntry{CreatedAt: time.Now(), Findings: stored})
+	if err != nil {
+		s.logger.Warn("failed to encode scan cache entry", "error", err)
+		return
+	}
+
+	file, err := os.CreateTemp(dir, "entry-*.tmp")
+	if err != nil {
+		s.logger.Warn("failed to write scan cache entry", "error", err)
+		return
+	}
+	_, writeErr := file.Write(data)
+	closeErr := file.Close()
+	if writeErr != nil || closeErr != nil {
+		os.Remove(file.Name())
+		s.logger.Warn("failed to write scan cache entry", "error", errors.Join(writeErr, closeErr))
+		return
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		os.Remove(file.Name())
+		s.logger.Warn("failed to write scan cache entry", "error", err)
+		return
+	}
+
+	s.prune(dir)
+}
+
+// prune removes expired entries, at most once per TTL
+func (s *CachedScanner) prune(dir string) {
+	marker := filepath.Join(dir, pruneMarker)
+	if info, err := os.Stat(marker); err == nil && time.Since(info.ModTime()) < s.ttl {
+		return
+	}
+
+	// Touch the marker first so concurrent hooks do not all sweep
+	if err := os.WriteFile(marker, nil, 0600); err != nil {
+		return
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) <= s.ttl {
+			continue
+		}
+		if os.Remove(filepath.Join(dir, entry.Name())) == nil {
+			removed++
+		}
+	}
+
+	if removed > 0 {
+		s.logger.Debug("pruned expired scan cache entries", "count", removed)
+	}
+}
+
+// scannerFingerprint hashes the configuration that affects scan results, so changing it invalidates the cache
+func scannerFingerprint(cfg *config.Config) string {
+	data, _ := json.Marshal(struct {
+		Version    int
+		Scanner    config.ScannerConfig
+		VaultRadar []any
+		Gitleaks   []any
+		Trufflehog []any
+	}{
+		Version:    cacheVersion,
+		Scanner:    cfg.Scanner,
+		VaultRadar: []any{cfg.VaultRadar.Command, cfg.VaultRadar.ScanCommand, cfg.VaultRadar.ExtraArgs, cfg.VaultRadar.ContentTransfer},
+		Gitleaks:   []any{cfg.Gitleaks.Command, cfg.Gitleaks.ConfigPath, cfg.Gitleaks.ExtraArgs},
+		Trufflehog: []any{cfg.Trufflehog.Command, cfg.Trufflehog.ExtraArgs},
+	})
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// rulesFingerprint hashes the contents of a scanner rules file, so editing the rules invalidates the cache
+// An unset or unreadable file hashes to the empty string; the scanner then fails and nothing is cached
+func rulesFingerprint(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// cacheDir returns the cache directory, creating it with 0700 permissions
+// An empty path defaults to "cache" under the config directory
+func cacheDir(path string) (string, error) {
+	if path == "" {
+		path = filepath.Join(config.GetDefaultConfigDir(), "cache")
+	}
+
+	path, err := config.ExpandHome(path)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return "", fmt.Errorf("failed to create cache directory; %w", err)
+	}
+
+	return path, nil
+}
@@ -0,0 +1,176 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+// commandOutput holds the output of an external scanner run
+type commandOutput struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// prepareScanFile writes content to a new temporary directory for an external scanner
+// The directory is created under baseDir, or the system temp directory when baseDir is empty
+// The file sits in its own subdirectory so the original file name can be reused; the caller must remove tempDir with removeScanDir
+func prepareScanFile(baseDir, tool string, content types.ScanContent) (tempDir, scanFile string, err error) {
+	tempDir, err = os.MkdirTemp(baseDir, tool+"-scan-*")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create temp directory; %w", err)
+	}
+
+	contentDir := filepath.Join(tempDir, "content")
+	if err := os.Mkdir(contentDir, 0700); err != nil {
+		removeScanDir(tempDir)
+		return "", "", fmt.Errorf("failed to create temp content directory; %w", err)
+	}
+
+	scanFile = filepath.Join(contentDir, scanFileName(content))
+	if err := os.WriteFile(scanFile, []byte(content.Content), 0600); err != nil {
+		removeScanDir(tempDir)
+		return "", "", fmt.Errorf("failed to write temp file; %w", err)
+	}
+
+	return tempDir, scanFile, nil
+}
+
+// removeScanDir overwrites every file under dir with zeros before removing the directory
+// Scanned content and scanner reports may hold secrets, so they are not left recoverable on disk
+func removeScanDir(dir string) {
+	filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err == nil && entry.Type().IsRegular() {
+			wipeFile(path)
+		}
+		return nil
+	})
+	os.RemoveAll(dir)
+}
+
+// wipeFile overwrites a file with zeros and flushes it to disk
+func wipeFile(path string) {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return
+	}
+
+	zeros := make([]byte, 32*1024)
+	for remaining := info.Size(); remaining > 0; {
+		n := int64(len(zeros))
+		if remaining < n {
+			n = remaining
+		}
+		if _, err := file.Write(zeros[:n]); err != nil {
+			return
+		}
+		remaining -= n
+	}
+
+	file.Sync()
+}
+
+// runCommand executes an external scanner, bounded by timeoutSeconds
+// A missing binary or an exceeded timeout is returned as a classified ScanError;
+// any other failure (e.g. a non-zero exit) is returned as is alongside the output
+func runCommand(ctx context.Context, tool, command string, args []string, timeoutSeconds int) (commandOutput, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(timeoutCtx, command, args...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+
+	output := commandOutput{
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		ExitCode: cmd.ProcessState.ExitCode(),
+	}
+
+	if err == nil {
+		return output, nil
+	}
+
+	// Check if the binary is missing, either from PATH or at a configured path
+	if errors.Is(err, exec.ErrNotFound) {
+		return output, &types.ScanError{
+			Kind: types.ScanErrorNotInstalled,
+			Err:  fmt.Errorf("%s command %q not found on PATH", tool, command),
+		}
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return output, &types.ScanError{
+			Kind: types.ScanErrorNotInstalled,
+			Err:  fmt.Errorf("%s command %q not found", tool, command),
+		}
+	}
+
+	// Check if it's a timeout
+	if timeoutCtx.Err() == context.DeadlineExceeded {
+		return output, &types.ScanError{
+			Kind: types.ScanErrorTimeout,
+			Err:  fmt.Errorf("%s scan timed out after %d seconds", tool, timeoutSeconds),
+		}
+	}
+
+	return output, err
+}
+
+// exitError describes a scanner that exited non-zero without usable output
+func exitError(tool string, output commandOutput) *types.ScanError {
+	return &types.ScanError{
+		Kind: types.ScanErrorExecution,
+		Err:  fmt.Errorf("%s exited with code %d: %s", tool, output.ExitCode, firstLine(output.Stderr)),
+	}
+}
+
+// locateSecret records the position of a secret reported by a scanner that identifies it by value
+// The secret is searched from the start of the reported line (1-based; 0 searches the whole content)
+// Without a match, the reported line and column are kept as is
+func locateSecret(finding *types.Finding, content, secret string, line, column int) {
+	if secret != "" {
+		from := 0
+		if line > 1 {
+			if offset, ok := offsetAt(content, line, 1); ok {
+				from = offset
+			}
+		}
+
+		if i := strings.Index(content[from:], secret); i >= 0 {
+			applyPosition(finding, rawPosition{StartOffset: from + i, EndOffset: from + i + len(secret)}, content)
+			return
+		}
+	}
+
+	applyPosition(finding, rawPosition{StartLine: line, StartColumn: column}, content)
+}
+
+// firstLine returns the first non-empty line of s, or "no error output" if there is none
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return "no error output"
+}
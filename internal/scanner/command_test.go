@@ -0,0 +1,130 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+// writeFakeBinary writes an executable shell script standing in for an external scanner
+func writeFakeBinary(t *testing.T, script string) string {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("fake scanner binaries are shell scripts")
+	}
+
+	path := filepath.Join(t.TempDir(), "fake-scanner")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0700); err != nil {
+		t.Fatalf("failed to write fake binary: %v", err)
+	}
+	return path
+}
+
+// readFixture returns the absolute path and contents of a scanner fixture
+func readFixture(t *testing.T, tool, name string) (string, string) {
+	t.Helper()
+
+	path, err := filepath.Abs(filepath.Join("..", "..", "testdata", tool, name))
+	if err != nil {
+		t.Fatalf("failed to resolve fixture: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	return path, string(data)
+}
+
+func TestRunCommand_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		command    func(t *testing.T) string
+		timeout    int
+		expectKind string // Empty for an unclassified error
+	}{
+		{
+			name:       "missing binary",
+			command:    func(t *testing.T) string { return "hook-vault-radar-no-such-scanner" },
+			timeout:    5,
+			expectKind: types.ScanErrorNotInstalled,
+		},
+		{
+			name:       "missing absolute path",
+			command:    func(t *testing.T) string { return filepath.Join(t.TempDir(), "vault-radar") },
+			timeout:    5,
+			expectKind: types.ScanErrorNotInstalled,
+		},
+		{
+			name:       "timeout",
+			command:    func(t *testing.T) string { return writeFakeBinary(t, "exec sleep 5\n") },
+			timeout:    1,
+			expectKind: types.ScanErrorTimeout,
+		},
+		{
+			name:    "non-zero exit",
+			command: func(t *testing.T) string { return writeFakeBinary(t, "echo 'bad flag' >&2\nexit 3\n") },
+			timeout: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := runCommand(context.Background(), "fake", tt.command(t), nil, tt.timeout)
+			if err == nil {
+				t.Fatal("runCommand() should fail")
+			}
+
+			var scanErr *types.ScanError
+			if tt.expectKind == "" {
+				if errors.As(err, &scanErr) {
+					t.Fatalf("error %v should not be classified", err)
+				}
+				if output.ExitCode != 3 || firstLine(output.Stderr) != "bad flag" {
+					t.Errorf("output = %+v, want exit code 3 with stderr", output)
+				}
+				return
+			}
+
+			if !errors.As(err, &scanErr) || scanErr.Kind != tt.expectKind {
+				t.Errorf("error %v is not a ScanError of kind %q", err, tt.expectKind)
+			}
+		})
+	}
+}
+
+func TestLocateSecret(t *testing.T) {
+	content := "token = abc\nother = xyz\ntoken = abc\n"
+
+	tests := []struct {
+		name        string
+		secret      string
+		line        int
+		expectStart int
+		expectLine  int
+	}{
+		{name: "first occurrence without line", secret: "abc", expectStart: 8, expectLine: 1},
+		{name: "occurrence on the reported line", secret: "abc", line: 3, expectStart: 32, expectLine: 3},
+		{name: "unknown secret keeps the line", secret: "nope", line: 2, expectLine: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var finding types.Finding
+			locateSecret(&finding, content, tt.secret, tt.line, 0)
+
+			if finding.StartOffset != tt.expectStart {
+				t.Errorf("StartOffset = %d, want %d", finding.StartOffset, tt.expectStart)
+			}
+			if finding.Line != tt.expectLine {
+				t.Errorf("Line = %d, want %d", finding.Line, tt.expectLine)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/decision"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const compositeScannerName = "composite"
+
+// CompositeScanner implements the Scanner interface by running several scanners and merging their findings
+// Each finding records the engines that reported it; failing engines are reported alongside the other engines' findings
+type CompositeScanner struct {
+	scanners []Scanner
+	logger   *slog.Logger
+}
+
+// NewCompositeScanner creates a new composite scanner over the given scanners
+// Earlier scanners take precedence when two scanners report the same secret
+func NewCompositeScanner(logger *slog.Logger, scanners ...Scanner) *CompositeScanner {
+	return &CompositeScanner{
+		scanners: scanners,
+		logger:   logger,
+	}
+}
+
+// Scan runs every scanner concurrently and merges their findings
+func (s *CompositeScanner) Scan(ctx context.Context, content types.ScanContent) (types.ScanResults, error) {
+	startTime := time.Now()
+
+	scannerResults := make([]types.ScanResults, len(s.scanners))
+	var wg sync.WaitGroup
+
+	for i, sc := range s.scanners {
+		wg.Add(1)
+		go func(i int, sc Scanner) {
+			defer wg.Done()
+
+			result, err := sc.Scan(ctx, content)
+			if err != nil && result.Error == nil {
+				result.Error = err
+			}
+			scannerResults[i] = result
+		}(i, sc)
+	}
+
+	wg.Wait()
+
+	results := types.ScanResults{
+		Findings: []types.Finding{},
+	}
+
+	var errs []error
+	for i, result := range scannerResults {
+		engine := s.scanners[i].GetName()
+
+		if result.Error != nil {
+			s.logger.Warn("scanner failed",
+				"scanner", engine,
+				"error", result.Error)
+			errs = append(errs, fmt.Errorf("%s: %w", engine, result.Error))
+		}
+
+		// A failing engine may still have reported findings before it failed
+		for _, finding := range result.Findings {
+			if j := duplicateFinding(results.Findings, finding); j >= 0 {
+				// Engines may rate the same secret differently; the higher severity wins
+				if decision.SeverityLevel(finding.Severity) > decision.SeverityLevel(results.Findings[j].Severity) {
+					results.Findings[j].Severity = finding.Severity
+				}
+				results.Findings[j].Engines = appendEngine(results.Findings[j].Engines, engine)
+				continue
+			}
+
+			finding.Engines = appendEngine(nil, engine)
+			results.Findings = append(results.Findings, finding)
+		}
+	}
+
+	results.HasFindings = len(results.Findings) > 0
+	results.ScanDuration = time.Since(startTime)
+
+	// Errors are kept even when other engines completed, so the decision engine can apply decision.on_scan_error
+	if len(errs) > 0 {
+		results.Error = &types.ScanError{Kind: compositeErrorKind(errs), Err: errors.Join(errs...)}
+		return results, results.Error
+	}
+
+	return results, nil
+}
+
+// duplicateFinding returns the index of the finding already reported for the same secret, or -1
+// Findings with spans are duplicates when their spans are identical (engines name types differently),
+// or overlap and have the same type; overlapping secrets of different types are kept apart
+// Findings without spans are compared by type, location and line
+func duplicateFinding(findings []types.Finding, finding types.Finding) int {
+	for i, existing := range findings {
+		if finding.HasSpan() && existing.HasSpan() {
+			identical := finding.StartOffset == existing.StartOffset && finding.EndOffset == existing.EndOffset
+			overlapping := finding.StartOffset < existing.EndOffset && existing.StartOffset < finding.EndOffset
+			if identical || (overlapping && strings.EqualFold(finding.Type, existing.Type)) {
+				return i
+			}
+			continue
+		}
+
+		if existing.Type == finding.Type && existing.Location == finding.Location && existing.Line == finding.Line {
+			return i
+		}
+	}
+	return -1
+}
+
+// appendEngine adds engine to engines unless it is already listed
+func appendEngine(engines []string, engine string) []string {
+	for _, existing := range engines {
+		if existing == engine {
+			return engines
+		}
+	}
+	return append(engines, engine)
+}
+
+// compositeErrorKind returns the kind of the first classified engine error, or an execution failure
+func compositeErrorKind(errs []error) string {
+	for _, err := range errs {
+		var scanErr *types.ScanError
+		if errors.As(err, &scanErr) {
+			return scanErr.Kind
+		}
+	}
+	return types.ScanErrorExecution
+}
+
+// GetName returns the scanner name
+func (s *CompositeScanner) GetName() string {
+	return compositeScannerName
+}
@@ -0,0 +1,141 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+// stubScanner returns fixed results
+type stubScanner struct {
+	name     string
+	findings []types.Finding
+	err      error
+}
+
+func (s *stubScanner) Scan(ctx context.Context, content types.ScanContent) (types.ScanResults, error) {
+	return types.ScanResults{HasFindings: len(s.findings) > 0, Findings: s.findings, Error: s.err}, s.err
+}
+
+func (s *stubScanner) GetName() string {
+	return s.name
+}
+
+func TestCompositeScanner_Scan(t *testing.T) {
+	vaultRadarFinding := types.Finding{Type: "aws_access_key_id", Severity: "high", StartOffset: 4, EndOffset: 24}
+	builtinDuplicate := types.Finding{Type: "aws_access_key_id", Severity: "high", StartOffset: 4, EndOffset: 24}
+	builtinExtra := types.Finding{Type: "jwt", Severity: "medium", StartOffset: 30, EndOffset: 90}
+	gitleaksRenamed := types.Finding{Type: "aws-access-token", Severity: "critical", StartOffset: 4, EndOffset: 24}
+	gitleaksOverlapping := types.Finding{Type: "generic_password", Severity: "low", StartOffset: 0, EndOffset: 30}
+	builtinOverlapping := types.Finding{Type: "aws_access_key_id", Severity: "high", StartOffset: 2, EndOffset: 24}
+	notInstalled := &types.ScanError{Kind: types.ScanErrorNotInstalled, Err: errors.New("vault-radar not found")}
+
+	tests := []struct {
+		name             string
+		scanners         []Scanner
+		expectTypes      []string
+		expectSeverities []string
+		expectEngines    [][]string
+		expectErr        bool
+		expectKind       string
+	}{
+		{
+			name: "findings are merged without duplicates",
+			scanners: []Scanner{
+				&stubScanner{name: "vault-radar", findings: []types.Finding{vaultRadarFinding}},
+				&stubScanner{name: "builtin", findings: []types.Finding{builtinDuplicate, builtinExtra}},
+			},
+			expectTypes:   []string{"aws_access_key_id", "jwt"},
+			expectEngines: [][]string{{"vault-radar", "builtin"}, {"builtin"}},
+		},
+		{
+			name: "identical spans merge across type names and keep the higher severity",
+			scanners: []Scanner{
+				&stubScanner{name: "vault-radar", findings: []types.Finding{vaultRadarFinding}},
+				&stubScanner{name: "gitleaks", findings: []types.Finding{gitleaksRenamed}},
+			},
+			expectTypes:      []string{"aws_access_key_id"},
+			expectSeverities: []string{"critical"},
+			expectEngines:    [][]string{{"vault-radar", "gitleaks"}},
+		},
+		{
+			name: "overlapping spans merge only with the same type",
+			scanners: []Scanner{
+				&stubScanner{name: "gitleaks", findings: []types.Finding{gitleaksOverlapping}},
+				&stubScanner{name: "vault-radar", findings: []types.Finding{vaultRadarFinding}},
+				&stubScanner{name: "builtin", findings: []types.Finding{builtinOverlapping}},
+			},
+			expectTypes:      []string{"generic_password", "aws_access_key_id"},
+			expectSeverities: []string{"low", "high"},
+			expectEngines:    [][]string{{"gitleaks"}, {"vault-radar", "builtin"}},
+		},
+		{
+			name: "partial failure keeps findings and records the error",
+			scanners: []Scanner{
+				&stubScanner{name: "vault-radar", err: notInstalled},
+				&stubScanner{name: "builtin", findings: []types.Finding{builtinExtra}},
+			},
+			expectTypes:   []string{"jwt"},
+			expectEngines: [][]string{{"builtin"}},
+			expectErr:     true,
+			expectKind:    types.ScanErrorNotInstalled,
+		},
+		{
+			name: "every scanner failing is an error",
+			scanners: []Scanner{
+				&stubScanner{name: "vault-radar", err: notInstalled},
+				&stubScanner{name: "builtin", err: errors.New("boom")},
+			},
+			expectTypes: []string{},
+			expectErr:   true,
+			expectKind:  types.ScanErrorNotInstalled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewCompositeScanner(slog.New(slog.NewTextHandler(io.Discard, nil)), tt.scanners...)
+
+			results, err := s.Scan(context.Background(), types.ScanContent{Type: "text", Content: "irrelevant"})
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("Scan() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if tt.expectErr {
+				var scanErr *types.ScanError
+				if !errors.As(err, &scanErr) || scanErr.Kind != tt.expectKind {
+					t.Errorf("error %v is not a ScanError of kind %q", err, tt.expectKind)
+				}
+				if !errors.Is(err, notInstalled) {
+					t.Errorf("error %v does not wrap the scanner errors", err)
+				}
+				if results.Error == nil {
+					t.Error("results.Error is not set")
+				}
+			}
+
+			if len(results.Findings) != len(tt.expectTypes) {
+				t.Fatalf("got %d findings %+v, want %d", len(results.Findings), results.Findings, len(tt.expectTypes))
+			}
+			for i, expectType := range tt.expectTypes {
+				if results.Findings[i].Type != expectType {
+					t.Errorf("finding %d type = %q, want %q", i, results.Findings[i].Type, expectType)
+				}
+			}
+			for i, expectSeverity := range tt.expectSeverities {
+				if results.Findings[i].Severity != expectSeverity {
+					t.Errorf("finding %d severity = %q, want %q", i, results.Findings[i].Severity, expectSeverity)
+				}
+			}
+			for i, expectEngines := range tt.expectEngines {
+				if strings.Join(results.Findings[i].Engines, ",") != strings.Join(expectEngines, ",") {
+					t.Errorf("finding %d engines = %v, want %v", i, results.Findings[i].Engines, expectEngines)
+				}
+			}
+		})
+	}
+}
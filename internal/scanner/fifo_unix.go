@@ -0,0 +1,23 @@
+//go:build unix
+
+package scanner
+
+import (
+	"os"
+	"syscall"
+)
+
+// mkfifo creates a named pipe readable and writable by the owner only
+func mkfifo(path string) error {
+	return syscall.Mkfifo(path, 0600)
+}
+
+// releaseFIFO unblocks a writer of the named pipe: opening a read end completes a pending open,
+// and closing it fails pending writes
+func releaseFIFO(path string) {
+	reader, err := os.OpenFile(path, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return
+	}
+	reader.Close()
+}
@@ -0,0 +1,13 @@
+//go:build windows
+
+package scanner
+
+import "fmt"
+
+// mkfifo is not supported; Windows has no named pipes in the file system
+func mkfifo(path string) error {
+	return fmt.Errorf("named pipes are not supported on windows")
+}
+
+// releaseFIFO does nothing, as no named pipe is ever created
+func releaseFIFO(path string) {}
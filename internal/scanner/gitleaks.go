@@ -0,0 +1,164 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const (
+	gitleaksScannerName = "gitleaks"
+
+	// gitleaksSeverity is assigned to every gitleaks finding; gitleaks rules carry no severity
+	gitleaksSeverity = "high"
+)
+
+// GitleaksScanner implements the Scanner interface using the gitleaks CLI
+type GitleaksScanner struct {
+	cfg    *config.Config
+	logger *slog.Logger
+}
+
+// gitleaksFinding is one entry of a gitleaks JSON report
+type gitleaksFinding struct {
+	RuleID      string `json:"RuleID"`
+	Description string `json:"Description"`
+	StartLine   int    `json:"StartLine"`
+	StartColumn int    `json:"StartColumn"`
+	Secret      string `json:"Secret"`
+}
+
+// NewGitleaksScanner creates a new gitleaks scanner instance
+func NewGitleaksScanner(cfg *config.Config, logger *slog.Logger) *GitleaksScanner {
+	return &GitleaksScanner{
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Scan executes gitleaks to scan the provided content
+func (s *GitleaksScanner) Scan(ctx context.Context, content types.ScanContent) (types.ScanResults, error) {
+	startTime := time.Now()
+
+	results := types.ScanResults{
+		HasFindings: false,
+		Findings:    []types.Finding{},
+	}
+
+	// Write content to a temporary file, named after the original file when known so path-based rules apply
+	tempDir, tempFile, err := prepareScanFile("", gitleaksScannerName, content)
+	if err != nil {
+		results.Error = &types.ScanError{Kind: types.ScanErrorExecution, Err: err}
+		return results, results.Error
+	}
+	defer removeScanDir(tempDir)
+
+	reportFile := filepath.Join(tempDir, "gitleaks-report.json")
+	cmdArgs := s.buildCommandArgs(tempFile, reportFile)
+
+	s.logger.Info("executing gitleaks",
+		"command", s.cfg.Gitleaks.Command,
+		"args", cmdArgs)
+
+	output, err := runCommand(ctx, gitleaksScannerName, s.cfg.Gitleaks.Command, cmdArgs, s.cfg.Gitleaks.TimeoutSeconds)
+
+	results.ScanDuration = time.Since(startTime)
+
+	// Leaks are reported with exit code 0 (--exit-code 0), so any failure is an error
+	if err != nil {
+		var scanErr *types.ScanError
+		if errors.As(err, &scanErr) {
+			results.Error = scanErr
+		} else {
+			results.Error = exitError(gitleaksScannerName, output)
+		}
+		return results, results.Error
+	}
+
+	findings, err := s.parseReport(reportFile, content)
+	if err != nil {
+		results.Error = &types.ScanError{Kind: types.ScanErrorUnparsableOutput, Err: err}
+		return results, results.Error
+	}
+
+	results.Findings = findings
+	results.HasFindings = len(findings) > 0
+
+	s.logger.Info("scan completed",
+		"scanner", gitleaksScannerName,
+		"has_findings", results.HasFindings,
+		"finding_count", len(findings),
+		"duration", results.ScanDuration)
+
+	return results, nil
+}
+
+// buildCommandArgs constructs the command arguments for gitleaks
+func (s *GitleaksScanner) buildCommandArgs(filePath, reportFile string) []string {
+	args := []string{
+		"detect", "--no-git",
+		"--source", filePath,
+		"--report-format", "json",
+		"--report-path", reportFile,
+		"--exit-code", "0",
+		"--no-banner",
+	}
+
+	if s.cfg.Gitleaks.ConfigPath != "" {
+		args = append(args, "--config", s.cfg.Gitleaks.ConfigPath)
+	}
+
+	return append(args, s.cfg.Gitleaks.ExtraArgs...)
+}
+
+// parseReport parses a gitleaks JSON report (an array of findings)
+func (s *GitleaksScanner) parseReport(reportFile string, content types.ScanContent) ([]types.Finding, error) {
+	findings := []types.Finding{}
+
+	data, err := os.ReadFile(reportFile)
+	if err != nil {
+		return findings, fmt.Errorf("failed to read gitleaks report; %w", err)
+	}
+
+	// Some gitleaks versions leave the report empty when there are no leaks
+	if len(data) == 0 {
+		return findings, nil
+	}
+
+	var report []gitleaksFinding
+	if err := json.Unmarshal(data, &report); err != nil {
+		return findings, fmt.Errorf("failed to parse gitleaks report; %w", err)
+	}
+
+	location := contentLocation(content)
+	for _, leak := range report {
+		finding := types.Finding{
+			Severity:    gitleaksSeverity,
+			Type:        leak.RuleID,
+			Location:    location,
+			Description: leak.Description,
+		}
+		locateSecret(&finding, content.Content, leak.Secret, leak.StartLine, leak.StartColumn)
+
+		findings = append(findings, finding)
+	}
+
+	s.logger.Debug("parsed gitleaks report",
+		"findings_count", len(findings),
+		"report_size", len(data))
+
+	return findings, nil
+}
+
+// GetName returns the scanner name
+func (s *GitleaksScanner) GetName() string {
+	return gitleaksScannerName
+}
@@ -0,0 +1,60 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+// maxConcurrentParts limits how many parts of a multi-part content are scanned at once
+const maxConcurrentParts = 4
+
+// ScanParts scans each part with the given scanner and merges the results
+// A failing part does not stop the others; its error is recorded in the merged results
+func ScanParts(ctx context.Context, s Scanner, parts []types.ScanContent) (types.ScanResults, error) {
+	startTime := time.Now()
+
+	partResults := make([]types.ScanResults, len(parts))
+	semaphore := make(chan struct{}, maxConcurrentParts)
+	var wg sync.WaitGroup
+
+	for i, part := range parts {
+		wg.Add(1)
+		go func(i int, part types.ScanContent) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			result, err := s.Scan(ctx, part)
+			if err != nil && result.Error == nil {
+				result.Error = err
+			}
+			partResults[i] = result
+		}(i, part)
+	}
+
+	wg.Wait()
+
+	results := types.ScanResults{
+		Findings: []types.Finding{},
+	}
+
+	var errs []error
+	for _, result := range partResults {
+		results.Findings = append(results.Findings, result.Findings...)
+		results.CacheHits += result.CacheHits
+		if result.Error != nil {
+			errs = append(errs, result.Error)
+		}
+	}
+
+	results.HasFindings = len(results.Findings) > 0
+	results.ScanDuration = time.Since(startTime)
+	results.Error = errors.Join(errs...)
+
+	return results, results.Error
+}
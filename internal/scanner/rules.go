@@ -0,0 +1,105 @@
+package scanner
+
+import (
+	"math"
+	"regexp"
+)
+
+// defaultMinEntropy is the Shannon entropy, in bits per character, a generic secret value must reach
+// Ordinary words and placeholders such as "changeme" stay below it; random tokens exceed it
+const defaultMinEntropy = 3.5
+
+// rule describes one class of secret recognized by the builtin scanner
+type rule struct {
+	id          string
+	description string
+	severity    string
+	pattern     *regexp.Regexp
+	group       int     // Capture group holding the secret; 0 means the whole match
+	minEntropy  float64 // Minimum Shannon entropy of the secret; 0 disables the check
+}
+
+// builtinRules is the rule pack of the builtin scanner
+// Specific rules come first: a match overlapping an earlier finding is discarded, so generic rules only
+// report what no specific rule already covered
+var builtinRules = []rule{
+	{
+		id:          "private_key",
+		description: "Private key",
+		severity:    "critical",
+		pattern:     regexp.MustCompile(`-----BEGIN[A-Z ]*PRIVATE KEY( BLOCK)?-----(?:[\s\S]*?-----END[A-Z ]*PRIVATE KEY( BLOCK)?-----)?`),
+	},
+	{
+		id:          "aws_access_key_id",
+		description: "AWS access key ID",
+		severity:    "high",
+		pattern:     regexp.MustCompile(`\b((?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16})\b`),
+		group:       1,
+	},
+	{
+		id:          "aws_secret_access_key",
+		description: "AWS secret access key",
+		severity:    "high",
+		pattern:     regexp.MustCompile(`(?i)aws_?secret_?(?:access_?)?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+]{40})\b`),
+		group:       1,
+		minEntropy:  defaultMinEntropy,
+	},
+	{
+		id:          "github_token",
+		description: "GitHub token",
+		severity:    "high",
+		pattern:     regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{82})\b`),
+		group:       1,
+	},
+	{
+		id:          "slack_token",
+		description: "Slack token",
+		severity:    "high",
+		pattern:     regexp.MustCompile(`\b(xox[abposr]-[0-9A-Za-z-]{10,250})\b`),
+		group:       1,
+	},
+	{
+		id:          "slack_webhook_url",
+		description: "Slack incoming webhook URL",
+		severity:    "medium",
+		pattern:     regexp.MustCompile(`https://hooks\.slack\.com/services/T[A-Z0-9]+/B[A-Z0-9]+/[A-Za-z0-9]+`),
+	},
+	{
+		id:          "jwt",
+		description: "JSON Web Token",
+		severity:    "medium",
+		pattern:     regexp.MustCompile(`\b(eyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,})`),
+		group:       1,
+	},
+	{
+		id:          "generic_secret",
+		description: "High-entropy value assigned to a secret-like name",
+		severity:    "medium",
+		pattern: regexp.MustCompile(`(?i)[a-z0-9_.-]*(?:secret|token|passw(?:or)?d|pwd|api_?key|access_?key|auth_?key|credential)[a-z0-9_.-]*` +
+			`["']?\s*(?::=|=>|[:=])\s*["']?([A-Za-z0-9+/=_.~!@#$%^&*-]{16,})`),
+		group:      1,
+		minEntropy: defaultMinEntropy,
+	},
+}
+
+// shannonEntropy returns the Shannon entropy of s in bits per character
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
@@ -2,10 +2,22 @@ package scanner
 
 import (
 	"context"
+	"log/slog"
+	"strings"
 
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
 	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
 )
 
+// Scanner types selectable with scanner.type
+const (
+	TypeVaultRadar = "vault-radar" // Shell out to the Vault Radar CLI
+	TypeBuiltin    = "builtin"     // Native regex and entropy rule pack
+	TypeGitleaks   = "gitleaks"    // Shell out to the gitleaks CLI
+	TypeTrufflehog = "trufflehog"  // Shell out to the TruffleHog CLI
+	TypeComposite  = "composite"   // Every engine in scanner.engines, merged
+)
+
 // Scanner defines the interface for security scanners
 type Scanner interface {
 	// Scan scans content for secrets and sensitive data
@@ -14,3 +26,66 @@ type Scanner interface {
 	// GetName returns the scanner name
 	GetName() string
 }
+
+// NewScanner creates the scanner selected by scanner.type, behind the result cache when cache.enabled is set
+// Unknown types fall back to Vault Radar
+func NewScanner(cfg *config.Config, logger *slog.Logger) Scanner {
+	s := selectScanner(cfg, logger)
+	if cfg.Cache.Enabled {
+		return NewCachedScanner(s, cfg, logger)
+	}
+	return s
+}
+
+// selectScanner creates the scanner selected by scanner.type
+func selectScanner(cfg *config.Config, logger *slog.Logger) Scanner {
+	switch strings.ToLower(cfg.Scanner.Type) {
+	case TypeBuiltin:
+		return NewBuiltinScanner(logger)
+	case TypeGitleaks:
+		return NewGitleaksScanner(cfg, logger)
+	case TypeTrufflehog:
+		return NewTrufflehogScanner(cfg, logger)
+	case TypeComposite:
+		return NewCompositeScanner(logger, compositeEngines(cfg, logger)...)
+	case TypeVaultRadar, "":
+		return NewVaultRadarScanner(cfg, logger)
+	default:
+		logger.Warn("unknown scanner type, using vault-radar", "type", cfg.Scanner.Type)
+		return NewVaultRadarScanner(cfg, logger)
+	}
+}
+
+// compositeEngines creates the scanners listed in scanner.engines, skipping unknown and duplicate names
+// Vault Radar and the builtin rule pack are used when no valid engine is listed
+func compositeEngines(cfg *config.Config, logger *slog.Logger) []Scanner {
+	var engines []Scanner
+	seen := make(map[string]bool)
+
+	for _, name := range cfg.Scanner.Engines {
+		name = strings.ToLower(name)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		switch name {
+		case TypeVaultRadar:
+			engines = append(engines, NewVaultRadarScanner(cfg, logger))
+		case TypeBuiltin:
+			engines = append(engines, NewBuiltinScanner(logger))
+		case TypeGitleaks:
+			engines = append(engines, NewGitleaksScanner(cfg, logger))
+		case TypeTrufflehog:
+			engines = append(engines, NewTrufflehogScanner(cfg, logger))
+		default:
+			logger.Warn("unknown composite scanner engine, skipping", "engine", name)
+		}
+	}
+
+	if len(engines) == 0 {
+		return []Scanner{NewVaultRadarScanner(cfg, logger), NewBuiltinScanner(logger)}
+	}
+
+	return engines
+}
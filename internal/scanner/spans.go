@@ -0,0 +1,110 @@
+package scanner
+
+import (
+	"unicode/utf8"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+// rawPosition holds positional fields as reported by a scanner; zero values mean "not reported"
+// Lines and columns are 1-based with inclusive end columns counted in characters; offsets are 0-based bytes
+type rawPosition struct {
+	StartOffset int
+	EndOffset   int
+	StartLine   int
+	StartColumn int
+	EndLine     int
+	EndColumn   int
+}
+
+// applyPosition records where a secret sits in the scanned content
+// Byte offsets are preferred; otherwise they are derived from a line/column range when one is reported
+func applyPosition(finding *types.Finding, pos rawPosition, content string) {
+	if pos.EndOffset > pos.StartOffset && pos.StartOffset >= 0 && pos.EndOffset <= len(content) {
+		finding.StartOffset, finding.EndOffset = pos.StartOffset, pos.EndOffset
+	} else if start, end, ok := spanFromPositions(content, pos); ok {
+		finding.StartOffset, finding.EndOffset = start, end
+	}
+
+	if finding.HasSpan() {
+		finding.Line, finding.Column = positionAt(content, finding.StartOffset)
+		return
+	}
+
+	// Keep whatever position was reported even though the secret cannot be located precisely
+	finding.Line, finding.Column = pos.StartLine, pos.StartColumn
+}
+
+// spanFromPositions converts a line/column range into byte offsets
+func spanFromPositions(content string, pos rawPosition) (start, end int, ok bool) {
+	if pos.EndLine == 0 {
+		pos.EndLine = pos.StartLine
+	}
+
+	start, ok = offsetAt(content, pos.StartLine, pos.StartColumn)
+	if !ok {
+		return 0, 0, false
+	}
+
+	last, ok := offsetAt(content, pos.EndLine, pos.EndColumn)
+	if !ok || last < start {
+		return 0, 0, false
+	}
+
+	// The end column is inclusive, so include the full final character
+	_, size := utf8.DecodeRuneInString(content[last:])
+	return start, last + size, true
+}
+
+// offsetAt returns the byte offset of a 1-based line and column, or false if it lies outside content
+func offsetAt(content string, line, column int) (int, bool) {
+	if line < 1 || column < 1 {
+		return 0, false
+	}
+
+	offset := 0
+	for l := 1; l < line; l++ {
+		next := indexByteFrom(content, '\n', offset)
+		if next < 0 {
+			return 0, false
+		}
+		offset = next + 1
+	}
+
+	for c := 1; c < column; c++ {
+		if offset >= len(content) || content[offset] == '\n' {
+			return 0, false
+		}
+		_, size := utf8.DecodeRuneInString(content[offset:])
+		offset += size
+	}
+
+	if offset >= len(content) || content[offset] == '\n' {
+		return 0, false
+	}
+
+	return offset, true
+}
+
+// positionAt returns the 1-based line and column of a byte offset
+func positionAt(content string, offset int) (line, column int) {
+	line, lineStart := 1, 0
+	for i := 0; i < offset && i < len(content); i++ {
+		if content[i] == '\n' {
+			line++
+			lineStart = i + 1
+		}
+	}
+
+	return line, utf8.RuneCountInString(content[lineStart:offset]) + 1
+}
+
+// indexByteFrom returns the index of the first b in s at or after from, or -1
+func indexByteFrom(s string, b byte, from int) int {
+	for i := from; i < len(s); i++ {
+		if s[i] == b {
+			return i
+		}
+	}
+	return -1
+}
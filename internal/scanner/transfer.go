@@ -0,0 +1,146 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+// Content transfer mechanisms selectable with vault_radar.content_transfer
+const (
+	TransferTempFile   = "temp_file"   // Temporary file under the system temp directory
+	TransferPipe       = "pipe"        // Named pipe (FIFO) with the original file name; the content never touches disk
+	TransferPrivateDir = "private_dir" // Temporary file under a 0700 directory (vault_radar.scan_dir), e.g. on tmpfs
+)
+
+// fifoReleaseInterval is how often Cleanup retries releasing a blocked pipe writer
+const fifoReleaseInterval = 10 * time.Millisecond
+
+// contentStage holds the content handed to an external scanner and the private directory for its output
+type contentStage struct {
+	Dir  string // Temporary directory for scanner output; wiped and removed by Cleanup
+	Path string // Path the scanner reads the content from
+
+	cleanup func()
+}
+
+// Cleanup stops feeding the pipe and wipes the temporary directory
+// It is deferred right after staging so it also runs when the scan is cancelled or panics
+func (s *contentStage) Cleanup() {
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+	removeScanDir(s.Dir)
+}
+
+// stageContent makes content readable by an external scanner using the configured transfer mechanism
+func stageContent(cfg config.VaultRadarConfig, tool string, content types.ScanContent) (*contentStage, error) {
+	switch strings.ToLower(cfg.ContentTransfer) {
+	case TransferPipe:
+		return stagePipe(tool, content)
+	case TransferPrivateDir:
+		dir, err := privateScanDir(cfg.ScanDir)
+		if err != nil {
+			return nil, err
+		}
+		return stageFile(dir, tool, content)
+	case TransferTempFile, "":
+		return stageFile("", tool, content)
+	default:
+		return nil, fmt.Errorf("unknown content transfer %q (expected %s, %s or %s)", cfg.ContentTransfer, TransferTempFile, TransferPipe, TransferPrivateDir)
+	}
+}
+
+// stageFile writes content to a temporary file under baseDir
+func stageFile(baseDir, tool string, content types.ScanContent) (*contentStage, error) {
+	dir, path, err := prepareScanFile(baseDir, tool, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contentStage{Dir: dir, Path: path}, nil
+}
+
+// stagePipe streams content to the scanner through a named pipe in a private temporary directory
+// The pipe carries the original file name, so file-type-aware rules still apply; only the scanner output is written to disk
+func stagePipe(tool string, content types.ScanContent) (*contentStage, error) {
+	if runtime.GOOS == "windows" {
+		return nil, fmt.Errorf("content transfer %q is not supported on windows", TransferPipe)
+	}
+
+	dir, err := os.MkdirTemp("", tool+"-scan-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory; %w", err)
+	}
+
+	contentDir := filepath.Join(dir, "content")
+	if err := os.Mkdir(contentDir, 0700); err != nil {
+		removeScanDir(dir)
+		return nil, fmt.Errorf("failed to create temp content directory; %w", err)
+	}
+
+	path := filepath.Join(contentDir, scanFileName(content))
+	if err := mkfifo(path); err != nil {
+		removeScanDir(dir)
+		return nil, fmt.Errorf("failed to create pipe; %w", err)
+	}
+
+	// Opening the write end blocks until the scanner opens the pipe, so the pipe is fed concurrently
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		writer, err := os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			return
+		}
+		writer.WriteString(content.Content)
+		writer.Close()
+	}()
+
+	return &contentStage{
+		Dir:  dir,
+		Path: path,
+		cleanup: func() {
+			// A scanner that never opened the pipe or stopped reading early would leave the writer blocked;
+			// release it until it returns, as it may not have reached its open yet
+			for {
+				releaseFIFO(path)
+				select {
+				case <-done:
+					return
+				case <-time.After(fifoReleaseInterval):
+				}
+			}
+		},
+	}, nil
+}
+
+// privateScanDir returns the directory for private_dir transfers, creating it with 0700 permissions
+// An empty path defaults to "scan" under the config directory
+func privateScanDir(path string) (string, error) {
+	if path == "" {
+		path = filepath.Join(config.GetDefaultConfigDir(), "scan")
+	}
+
+	path, err := config.ExpandHome(path)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return "", fmt.Errorf("failed to create scan directory; %w", err)
+	}
+
+	// Tighten permissions of a pre-existing directory
+	if err := os.Chmod(path, 0700); err != nil {
+		return "", fmt.Errorf("failed to secure scan directory; %w", err)
+	}
+
+	return path, nil
+}
@@ -0,0 +1,159 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/leefowlercu/agent-hook-vault-radar/internal/config"
+	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
+)
+
+const (
+	trufflehogScannerName = "trufflehog"
+
+	// Verified secrets are live credentials, so they are reported one severity higher
+	trufflehogSeverity         = "high"
+	trufflehogVerifiedSeverity = "critical"
+)
+
+// TrufflehogScanner implements the Scanner interface using the TruffleHog CLI
+type TrufflehogScanner struct {
+	cfg    *config.Config
+	logger *slog.Logger
+}
+
+// trufflehogResult is one line of TruffleHog's --json output
+type trufflehogResult struct {
+	SourceMetadata struct {
+		Data struct {
+			Filesystem struct {
+				Line int `json:"line"`
+			} `json:"Filesystem"`
+		} `json:"Data"`
+	} `json:"SourceMetadata"`
+	DetectorName string `json:"DetectorName"`
+	Verified     bool   `json:"Verified"`
+	Raw          string `json:"Raw"`
+}
+
+// NewTrufflehogScanner creates a new TruffleHog scanner instance
+func NewTrufflehogScanner(cfg *config.Config, logger *slog.Logger) *TrufflehogScanner {
+	return &TrufflehogScanner{
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Scan executes trufflehog to scan the provided content
+func (s *TrufflehogScanner) Scan(ctx context.Context, content types.ScanContent) (types.ScanResults, error) {
+	startTime := time.Now()
+
+	results := types.ScanResults{
+		HasFindings: false,
+		Findings:    []types.Finding{},
+	}
+
+	tempDir, tempFile, err := prepareScanFile("", trufflehogScannerName, content)
+	if err != nil {
+		results.Error = &types.ScanError{Kind: types.ScanErrorExecution, Err: err}
+		return results, results.Error
+	}
+	defer removeScanDir(tempDir)
+
+	cmdArgs := s.buildCommandArgs(tempFile)
+
+	s.logger.Info("executing trufflehog",
+		"command", s.cfg.Trufflehog.Command,
+		"args", cmdArgs)
+
+	output, err := runCommand(ctx, trufflehogScannerName, s.cfg.Trufflehog.Command, cmdArgs, s.cfg.Trufflehog.TimeoutSeconds)
+
+	results.ScanDuration = time.Since(startTime)
+
+	// trufflehog exits 0 with results unless --fail is given, so any other failure is an error
+	if err != nil {
+		var scanErr *types.ScanError
+		if errors.As(err, &scanErr) {
+			results.Error = scanErr
+		} else {
+			results.Error = exitError(trufflehogScannerName, output)
+		}
+		return results, results.Error
+	}
+
+	findings, err := s.parseOutput(output.Stdout, content)
+	if err != nil {
+		results.Error = &types.ScanError{Kind: types.ScanErrorUnparsableOutput, Err: err}
+		return results, results.Error
+	}
+
+	results.Findings = findings
+	results.HasFindings = len(findings) > 0
+
+	s.logger.Info("scan completed",
+		"scanner", trufflehogScannerName,
+		"has_findings", results.HasFindings,
+		"finding_count", len(findings),
+		"duration", results.ScanDuration)
+
+	return results, nil
+}
+
+// buildCommandArgs constructs the command arguments for trufflehog
+func (s *TrufflehogScanner) buildCommandArgs(filePath string) []string {
+	args := []string{"filesystem", filePath, "--json", "--no-update"}
+	return append(args, s.cfg.Trufflehog.ExtraArgs...)
+}
+
+// parseOutput parses TruffleHog's newline-delimited JSON results
+func (s *TrufflehogScanner) parseOutput(stdout string, content types.ScanContent) ([]types.Finding, error) {
+	findings := []types.Finding{}
+
+	location := contentLocation(content)
+	invalidLines := 0
+	for lineNum, line := range strings.Split(stdout, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var result trufflehogResult
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			s.logger.Warn("failed to parse JSON line",
+				"line_num", lineNum+1,
+				"error", err)
+			invalidLines++
+			continue
+		}
+
+		finding := types.Finding{
+			Severity:    trufflehogSeverity,
+			Type:        strings.ToLower(result.DetectorName),
+			Location:    location,
+			Description: result.DetectorName + " secret",
+		}
+		if result.Verified {
+			finding.Severity = trufflehogVerifiedSeverity
+			finding.Description += " (verified live)"
+		}
+		locateSecret(&finding, content.Content, result.Raw, result.SourceMetadata.Data.Filesystem.Line, 0)
+
+		findings = append(findings, finding)
+	}
+
+	if len(findings) == 0 && invalidLines > 0 {
+		return findings, fmt.Errorf("trufflehog output contained %d unparsable lines and no findings", invalidLines)
+	}
+
+	return findings, nil
+}
+
+// GetName returns the scanner name
+func (s *TrufflehogScanner) GetName() string {
+	return trufflehogScannerName
+}
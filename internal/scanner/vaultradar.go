@@ -1,13 +1,12 @@
 package scanner
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
@@ -16,7 +15,12 @@ import (
 	"github.com/leefowlercu/agent-hook-vault-radar/pkg/types"
 )
 
-const scannerName = "vault-radar"
+const (
+	scannerName = "vault-radar"
+
+	// defaultScanFileName is used when the content has no originating file path
+	defaultScanFileName = "scan-content.txt"
+)
 
 // VaultRadarScanner implements the Scanner interface using Vault Radar CLI
 type VaultRadarScanner struct {
@@ -41,79 +45,83 @@ func (s *VaultRadarScanner) Scan(ctx context.Context, content types.ScanContent)
 		Findings:    []types.Finding{},
 	}
 
-	// Create a temporary directory for scanning
-	tempDir, err := os.MkdirTemp("", "vault-radar-scan-*")
+	// Hand the content over using the configured transfer mechanism; the stage is wiped even on cancellation or panic
+	stage, err := stageContent(s.cfg.VaultRadar, scannerName, content)
 	if err != nil {
-		results.Error = fmt.Errorf("failed to create temp directory; %w", err)
-		return results, results.Error
-	}
-	defer os.RemoveAll(tempDir)
-
-	// Write content to a temporary file
-	tempFile := filepath.Join(tempDir, "scan-content.txt")
-	if err := os.WriteFile(tempFile, []byte(content.Content), 0600); err != nil {
-		results.Error = fmt.Errorf("failed to write temp file; %w", err)
+		results.Error = &types.ScanError{Kind: types.ScanErrorExecution, Err: err}
 		return results, results.Error
 	}
+	defer stage.Cleanup()
 
 	// Create output file for vault-radar results
-	outputFile := filepath.Join(tempDir, "vault-radar-output.json")
+	outputFile := filepath.Join(stage.Dir, "vault-radar-output.json")
 
-	s.logger.Debug("created temporary file for scanning",
-		"file", tempFile,
+	s.logger.Debug("staged content for scanning",
+		"transfer", s.cfg.VaultRadar.ContentTransfer,
+		"path", stage.Path,
 		"output_file", outputFile,
 		"content_length", len(content.Content))
 
 	// Build vault-radar command
-	cmdArgs := s.buildCommandArgs(tempFile, outputFile)
+	cmdArgs := s.buildCommandArgs(stage.Path, outputFile)
 
 	s.logger.Info("executing vault-radar",
 		"command", s.cfg.VaultRadar.Command,
 		"args", cmdArgs)
 
-	// Create command with context for timeout
-	timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(s.cfg.VaultRadar.TimeoutSeconds)*time.Second)
-	defer cancel()
-
-	cmd := exec.CommandContext(timeoutCtx, s.cfg.VaultRadar.Command, cmdArgs...)
-
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
 	// Execute command
-	err = cmd.Run()
+	output, err := runCommand(ctx, scannerName, s.cfg.VaultRadar.Command, cmdArgs, s.cfg.VaultRadar.TimeoutSeconds)
 
 	results.ScanDuration = time.Since(startTime)
 
 	s.logger.Debug("vault-radar execution completed",
 		"duration", results.ScanDuration,
-		"exit_code", cmd.ProcessState.ExitCode())
+		"exit_code", output.ExitCode)
 
 	// vault-radar returns non-zero exit code if secrets are found or on error
 	if err != nil {
-		// Check if it's a timeout
-		if timeoutCtx.Err() == context.DeadlineExceeded {
-			results.Error = fmt.Errorf("vault-radar scan timed out after %d seconds", s.cfg.VaultRadar.TimeoutSeconds)
+		// A missing binary or a timeout leaves nothing to parse
+		var scanErr *types.ScanError
+		if errors.As(err, &scanErr) {
+			results.Error = scanErr
 			return results, results.Error
 		}
 
 		// Log stdout/stderr for debugging when vault-radar fails
 		s.logger.Warn("vault-radar returned non-zero exit code",
 			"error", err,
-			"exit_code", cmd.ProcessState.ExitCode(),
-			"stdout", stdout.String(),
-			"stderr", stderr.String())
+			"exit_code", output.ExitCode,
+			"stdout", output.Stdout,
+			"stderr", output.Stderr)
 	}
 
 	// Parse output file to extract findings
-	findings, err := s.parseOutputFile(outputFile)
-	if err != nil {
+	findings, parseErr := s.parseOutputFile(outputFile, content.Content)
+	if parseErr != nil {
 		s.logger.Warn("failed to parse vault-radar output file",
-			"error", err,
+			"error", parseErr,
 			"output_file", outputFile)
-		// Continue with empty findings rather than failing
-		findings = []types.Finding{}
+
+		// Without output, a failed run is an execution error rather than a clean scan
+		if err != nil {
+			results.Error = exitError(scannerName, output)
+		} else {
+			results.Error = &types.ScanError{Kind: types.ScanErrorUnparsableOutput, Err: parseErr}
+		}
+		return results, results.Error
+	}
+
+	// vault-radar only exits non-zero without findings when the scan itself failed
+	if err != nil && len(findings) == 0 {
+		results.Error = exitError(scannerName, output)
+		return results, results.Error
+	}
+
+	// Report the real origin of the content rather than the temporary file
+	if location := contentLocation(content); location != "" {
+		for i := range findings {
+			findings[i].Location = location
+		}
 	}
 
 	results.Findings = findings
@@ -127,6 +135,31 @@ func (s *VaultRadarScanner) Scan(ctx context.Context, content types.ScanContent)
 	return results, nil
 }
 
+// scanFileName returns the name of the temporary file to scan
+// The original file name is kept when known so Vault Radar's file-type-aware rules apply
+func scanFileName(content types.ScanContent) string {
+	filePath := content.Metadata["file_path"]
+	if filePath == "" {
+		return defaultScanFileName
+	}
+
+	name := filepath.Base(filePath)
+	if name == "." || name == ".." || name == string(filepath.Separator) {
+		return defaultScanFileName
+	}
+
+	return name
+}
+
+// contentLocation returns where the scanned content originated, or an empty string if unknown
+// An explicit "location" (e.g. a transcript line range) takes precedence over "file_path"
+func contentLocation(content types.ScanContent) string {
+	if location := content.Metadata["location"]; location != "" {
+		return location
+	}
+	return content.Metadata["file_path"]
+}
+
 // buildCommandArgs constructs the command arguments for vault-radar
 func (s *VaultRadarScanner) buildCommandArgs(filePath, outputFile string) []string {
 	// Start with the scan command (e.g., "scan file")
@@ -152,7 +185,8 @@ func (s *VaultRadarScanner) buildCommandArgs(filePath, outputFile string) []stri
 
 // parseOutputFile parses vault-radar output file and extracts findings
 // Vault-radar outputs newline-delimited JSON (NDJSON) - one JSON object per line
-func (s *VaultRadarScanner) parseOutputFile(outputFile string) ([]types.Finding, error) {
+// The scanned content is used to resolve where each secret sits so it can be redacted
+func (s *VaultRadarScanner) parseOutputFile(outputFile string, scanned string) ([]types.Finding, error) {
 	findings := []types.Finding{}
 
 	// Check if output file exists
@@ -175,6 +209,7 @@ func (s *VaultRadarScanner) parseOutputFile(outputFile string) ([]types.Finding,
 	// Parse newline-delimited JSON (NDJSON)
 	// Each line is a separate JSON object representing a finding
 	lines := strings.Split(string(data), "\n")
+	invalidLines := 0
 	for lineNum, line := range lines {
 		// Skip empty lines
 		line = strings.TrimSpace(line)
@@ -187,6 +222,7 @@ func (s *VaultRadarScanner) parseOutputFile(outputFile string) ([]types.Finding,
 			s.logger.Warn("failed to parse JSON line",
 				"line_num", lineNum+1,
 				"error", err)
+			invalidLines++
 			continue
 		}
 
@@ -211,9 +247,24 @@ func (s *VaultRadarScanner) parseOutputFile(outputFile string) ([]types.Finding,
 			finding.Severity = strings.ToLower(severity)
 		}
 
+		// Positional fields differ between vault-radar versions; accept both the long and short names
+		applyPosition(&finding, rawPosition{
+			StartOffset: intField(secretMap, "start_offset", "offset"),
+			EndOffset:   intField(secretMap, "end_offset"),
+			StartLine:   intField(secretMap, "start_line", "line", "line_number"),
+			StartColumn: intField(secretMap, "start_column", "column"),
+			EndLine:     intField(secretMap, "end_line"),
+			EndColumn:   intField(secretMap, "end_column"),
+		}, scanned)
+
 		findings = append(findings, finding)
 	}
 
+	// Skipping a few malformed lines is tolerable, but output with no parsable findings at all is not
+	if len(findings) == 0 && invalidLines > 0 {
+		return findings, fmt.Errorf("vault-radar output contained %d unparsable lines and no findings", invalidLines)
+	}
+
 	s.logger.Debug("parsed vault-radar output",
 		"findings_count", len(findings),
 		"output_size", len(data))
@@ -221,6 +272,16 @@ func (s *VaultRadarScanner) parseOutputFile(outputFile string) ([]types.Finding,
 	return findings, nil
 }
 
+// intField returns the first of keys holding a JSON number, or 0
+func intField(m map[string]any, keys ...string) int {
+	for _, key := range keys {
+		if value, ok := m[key].(float64); ok {
+			return int(value)
+		}
+	}
+	return 0
+}
+
 // GetName returns the scanner name
 func (s *VaultRadarScanner) GetName() string {
 	return scannerName
@@ -7,29 +7,80 @@ type ScanContent struct {
 	Type     string            // "text", "file", "directory"
 	Content  string            // The content to scan
 	Metadata map[string]string // Additional context
+	Parts    []ScanContent     // Independently scanned sub-contents (e.g. one per file); Content is ignored when set
 }
 
 // Finding represents a single security finding from a scan
 type Finding struct {
-	Severity    string // "high", "medium", "low"
-	Type        string // "secret", "credential", "api_key", etc.
-	Location    string // Where the finding was detected
-	Description string // Human-readable description
+	Severity    string `json:"Severity"`               // "high", "medium", "low"
+	Type        string `json:"Type"`                   // "secret", "credential", "api_key", etc.
+	Location    string `json:"Location"`               // Where the finding was detected
+	Description string `json:"Description"`            // Human-readable description
+	Line        int    `json:"line,omitempty"`         // 1-based line where the secret starts (0 if unknown)
+	Column      int    `json:"column,omitempty"`       // 1-based column where the secret starts (0 if unknown)
+	StartOffset int    `json:"start_offset,omitempty"` // Byte offset of the secret in the scanned content
+	EndOffset   int    `json:"end_offset,omitempty"`   // Byte offset just past the secret (0 if unknown)
+
+	Engines []string `json:"engines,omitempty"` // Scanners that reported the finding (set by the composite scanner)
+
+	Fingerprint   string `json:"fingerprint,omitempty"`   // Hash of the type and secret value, for allowlisting (set by the decision engine)
+	Suppressed    bool   `json:"suppressed,omitempty"`    // Matched by the allowlist; recorded but not acted on
+	Justification string `json:"justification,omitempty"` // Allowlist justification of a suppressed finding
+}
+
+// HasSpan reports whether the finding carries the byte offsets of the secret
+func (f Finding) HasSpan() bool {
+	return f.EndOffset > f.StartOffset
+}
+
+// Scan error kinds
+const (
+	ScanErrorNotInstalled     = "not_installed"     // Scanner binary not found
+	ScanErrorTimeout          = "timeout"           // Scanner did not finish in time
+	ScanErrorUnparsableOutput = "unparsable_output" // Scanner output could not be read or parsed
+	ScanErrorExecution        = "execution"         // Any other failure running the scanner
+)
+
+// ScanError describes why a scan could not complete
+type ScanError struct {
+	Kind string // One of the ScanError* kinds
+	Err  error  // Underlying error with details for the user
+}
+
+// Error returns the underlying error message
+func (e *ScanError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *ScanError) Unwrap() error {
+	return e.Err
 }
 
 // ScanResults contains the results of a Vault Radar scan
 type ScanResults struct {
-	HasFindings  bool
+	HasFindings  bool // Whether any finding is not suppressed
 	Findings     []Finding
 	ScanDuration time.Duration
 	Error        error
+	CacheHits    int // Number of contents (or parts) whose results came from the scan cache
 }
 
+// Decision actions, ordered from least to most restrictive
+const (
+	ActionAllow = "allow" // Proceed without interruption
+	ActionAsk   = "ask"   // Ask the human to confirm before proceeding
+	ActionDeny  = "deny"  // Block the action
+)
+
 // Decision represents the hook's decision on whether to proceed or block
 type Decision struct {
-	Block    bool           // Whether to block the action
-	Reason   string         // Human-readable explanation
-	Metadata map[string]any // Additional metadata for the hook framework
+	Block           bool           // Whether to block the action (true when Action is "deny")
+	Action          string         // "allow", "ask" or "deny"
+	Reason          string         // Human-readable explanation
+	RedactedContent string         // Scanned content with secrets masked (redact mode only)
+	AuditOnly       bool           // The framework cannot enforce the decision; it is only recorded
+	Metadata        map[string]any // Additional metadata for the hook framework
 }
 
 // HookInput represents parsed input from a hook framework